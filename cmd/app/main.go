@@ -1,6 +1,10 @@
 package main
 
-import "github.com/anton1ks96/college-app-core/internal/app"
+import (
+	_ "time/tzdata"
+
+	"github.com/anton1ks96/college-app-core/internal/app"
+)
 
 func main() {
 	app.Run()
@@ -8,9 +8,15 @@ import (
 	"syscall"
 	"time"
 
+	"github.com/anton1ks96/college-app-core/internal/calendar"
 	"github.com/anton1ks96/college-app-core/internal/config"
 	"github.com/anton1ks96/college-app-core/internal/handlers"
+	"github.com/anton1ks96/college-app-core/internal/httpmw"
+	"github.com/anton1ks96/college-app-core/internal/notify"
+	"github.com/anton1ks96/college-app-core/internal/repository"
 	"github.com/anton1ks96/college-app-core/internal/server"
+	"github.com/anton1ks96/college-app-core/internal/services"
+	"github.com/anton1ks96/college-app-core/internal/storage"
 	"github.com/anton1ks96/college-app-core/pkg/logger"
 )
 
@@ -20,7 +26,32 @@ func Run() {
 		logger.Fatal(err)
 	}
 
-	handler := handlers.NewHandler(cfg)
+	cal, err := calendar.Load(cfg.Calendar.File)
+	if err != nil {
+		logger.Fatal(err)
+	}
+
+	store, err := storage.NewBoltStorage(cfg.Storage.Path, cfg.Storage.Timeout, cfg.Storage.SnapshotRetention)
+	if err != nil {
+		logger.Fatal(err)
+	}
+
+	portalRepo := repository.NewPortalRepository(
+		cfg.Portal.URL,
+		cfg.Portal.AttendanceURL,
+		cfg.Portal.PerformanceSubjectsURL,
+		cfg.Portal.PerformanceScoreURL,
+	)
+	history := services.NewHistoryRecorder(store, cfg.Storage.QueueSize)
+	calendarService := services.NewCalendarService(cal)
+	performanceService := services.NewPerformanceService(portalRepo, history, cfg.Performance.Workers, cfg.Grading)
+	authMiddleware := httpmw.NewAuthMiddleware(cfg.Auth.ServiceURL, cfg.Auth.Timeout)
+
+	historyCtx, stopHistory := context.WithCancel(context.Background())
+	defer stopHistory()
+	go history.Run(historyCtx)
+
+	handler := handlers.NewHandler(cfg, store, history, portalRepo, calendarService, performanceService, authMiddleware)
 
 	router := handler.Init()
 
@@ -34,12 +65,27 @@ func Run() {
 
 	logger.Info(fmt.Sprintf("college-app-core started on port %s", cfg.Server.Port))
 
+	pollerCtx, stopPoller := context.WithCancel(context.Background())
+	defer stopPoller()
+
+	if cfg.Notifications.Enabled {
+		poller, err := newGradePoller(cfg, store, calendarService, performanceService)
+		if err != nil {
+			logger.Fatal(err)
+		}
+		go poller.Run(pollerCtx)
+
+		logger.Info(fmt.Sprintf("grade poller started with interval %s", cfg.Notifications.Interval))
+	}
+
 	quit := make(chan os.Signal, 1)
 	signal.Notify(quit, syscall.SIGINT, syscall.SIGTERM)
 	<-quit
 
 	logger.Info("shutting down server...")
 
+	stopPoller()
+
 	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 	defer cancel()
 
@@ -47,5 +93,28 @@ func Run() {
 		logger.Error(fmt.Errorf("server forced to shutdown: %w", err))
 	}
 
+	stopHistory()
+	history.Wait()
+
+	if err := store.Close(); err != nil {
+		logger.Error(fmt.Errorf("failed to close storage: %w", err))
+	}
+
 	logger.Info("server exited")
 }
+
+func newGradePoller(cfg *config.Config, store storage.Storage, calendarService *services.CalendarService, performanceService *services.PerformanceService) (*services.GradePoller, error) {
+	sink, err := notify.NewSink(cfg.Notifications)
+	if err != nil {
+		return nil, err
+	}
+
+	return services.NewGradePoller(
+		performanceService,
+		calendarService,
+		store,
+		sink,
+		cfg.Notifications.Interval,
+		cfg.Notifications.Workers,
+	), nil
+}
@@ -0,0 +1,235 @@
+package calendar
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/anton1ks96/college-app-core/internal/domain"
+	"github.com/spf13/viper"
+)
+
+const dateLayout = "2006-01-02"
+
+type period struct {
+	name  string
+	start time.Time
+	end   time.Time
+}
+
+type Calendar struct {
+	source    domain.AcademicCalendar
+	years     []period
+	terms     []period
+	holidays  []period
+	exams     []period
+	practices []period
+	workdays  map[string]bool
+	daysOff   map[string]bool
+	weekends  map[time.Weekday]bool
+}
+
+func Load(path string) (*Calendar, error) {
+	v := viper.New()
+	v.SetConfigFile(path)
+	v.SetConfigType("yml")
+
+	if err := v.ReadInConfig(); err != nil {
+		return nil, fmt.Errorf("failed to read calendar file: %w", err)
+	}
+
+	var src domain.AcademicCalendar
+	if err := v.Unmarshal(&src); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal calendar: %w", err)
+	}
+
+	return New(src)
+}
+
+func New(src domain.AcademicCalendar) (*Calendar, error) {
+	c := &Calendar{
+		source:   src,
+		workdays: make(map[string]bool),
+		daysOff:  make(map[string]bool),
+		weekends: make(map[time.Weekday]bool),
+	}
+
+	var err error
+	if c.terms, err = parsePeriods("term", src.Terms); err != nil {
+		return nil, err
+	}
+	if c.years, err = parsePeriods("year", src.Years); err != nil {
+		return nil, err
+	}
+	if len(c.years) == 0 && len(c.terms) > 0 {
+		year := period{start: c.terms[0].start, end: c.terms[0].end}
+		for _, t := range c.terms[1:] {
+			if t.start.Before(year.start) {
+				year.start = t.start
+			}
+			if t.end.After(year.end) {
+				year.end = t.end
+			}
+		}
+		c.years = []period{year}
+	}
+	if c.holidays, err = parsePeriods("holiday", src.Holidays); err != nil {
+		return nil, err
+	}
+	if c.exams, err = parsePeriods("exams", src.Exams); err != nil {
+		return nil, err
+	}
+	if c.practices, err = parsePeriods("practice", src.Practices); err != nil {
+		return nil, err
+	}
+
+	for _, tr := range src.Transfers {
+		from, err := parseDate(tr.From)
+		if err != nil {
+			return nil, fmt.Errorf("transfer %s -> %s: %w", tr.From, tr.To, err)
+		}
+		to, err := parseDate(tr.To)
+		if err != nil {
+			return nil, fmt.Errorf("transfer %s -> %s: %w", tr.From, tr.To, err)
+		}
+		c.workdays[from.Format(dateLayout)] = true
+		c.daysOff[to.Format(dateLayout)] = true
+	}
+
+	weekends := src.Weekends
+	if len(weekends) == 0 {
+		weekends = []string{"sunday"}
+		c.source.Weekends = weekends
+	}
+	for _, name := range weekends {
+		wd, ok := parseWeekday(name)
+		if !ok {
+			return nil, fmt.Errorf("unknown weekday %q", name)
+		}
+		c.weekends[wd] = true
+	}
+
+	return c, nil
+}
+
+func (c *Calendar) Source() domain.AcademicCalendar {
+	return c.source
+}
+
+func (c *Calendar) Day(day time.Time) domain.CalendarDay {
+	day = truncateDay(day)
+	kind, name := c.kind(day)
+
+	return domain.CalendarDay{
+		Date:    day.Format(dateLayout),
+		Weekday: strings.ToLower(day.Weekday().String()),
+		Kind:    kind,
+		Name:    name,
+	}
+}
+
+func (c *Calendar) IsStudyDay(day time.Time) bool {
+	kind, _ := c.kind(truncateDay(day))
+	return kind == domain.DayKindStudy
+}
+
+func (c *Calendar) TermAt(day time.Time) (domain.CalendarPeriod, bool) {
+	day = truncateDay(day)
+	if p, ok := find(c.terms, day); ok {
+		return toDomain(p), true
+	}
+	return domain.CalendarPeriod{}, false
+}
+
+func (c *Calendar) YearAt(day time.Time) (domain.CalendarPeriod, bool) {
+	day = truncateDay(day)
+	if p, ok := find(c.years, day); ok {
+		return toDomain(p), true
+	}
+	return domain.CalendarPeriod{}, false
+}
+
+func (c *Calendar) kind(day time.Time) (domain.DayKind, string) {
+	key := day.Format(dateLayout)
+
+	if p, ok := find(c.holidays, day); ok {
+		return domain.DayKindHoliday, p.name
+	}
+	if c.daysOff[key] {
+		return domain.DayKindHoliday, "transferred day off"
+	}
+	if p, ok := find(c.practices, day); ok {
+		return domain.DayKindPractice, p.name
+	}
+	if p, ok := find(c.exams, day); ok {
+		return domain.DayKindExams, p.name
+	}
+
+	term, inTerm := find(c.terms, day)
+	if _, inYear := find(c.years, day); !inTerm && inYear {
+		return domain.DayKindVacation, ""
+	}
+
+	if c.weekends[day.Weekday()] && !c.workdays[key] {
+		return domain.DayKindWeekend, ""
+	}
+
+	return domain.DayKindStudy, term.name
+}
+
+func find(periods []period, day time.Time) (period, bool) {
+	for _, p := range periods {
+		if !day.Before(p.start) && !day.After(p.end) {
+			return p, true
+		}
+	}
+	return period{}, false
+}
+
+func toDomain(p period) domain.CalendarPeriod {
+	return domain.CalendarPeriod{
+		Name:  p.name,
+		Start: p.start.Format(dateLayout),
+		End:   p.end.Format(dateLayout),
+	}
+}
+
+func parsePeriods(kind string, src []domain.CalendarPeriod) ([]period, error) {
+	periods := make([]period, 0, len(src))
+	for _, p := range src {
+		start, err := parseDate(p.Start)
+		if err != nil {
+			return nil, fmt.Errorf("%s %q: %w", kind, p.Name, err)
+		}
+		end, err := parseDate(p.End)
+		if err != nil {
+			return nil, fmt.Errorf("%s %q: %w", kind, p.Name, err)
+		}
+		if end.Before(start) {
+			return nil, fmt.Errorf("%s %q: end %s is before start %s", kind, p.Name, p.End, p.Start)
+		}
+		periods = append(periods, period{name: p.Name, start: start, end: end})
+	}
+	return periods, nil
+}
+
+func parseDate(value string) (time.Time, error) {
+	t, err := time.ParseInLocation(dateLayout, value, time.Local)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid date %q", value)
+	}
+	return t, nil
+}
+
+func parseWeekday(name string) (time.Weekday, bool) {
+	for wd := time.Sunday; wd <= time.Saturday; wd++ {
+		if strings.EqualFold(wd.String(), name) {
+			return wd, true
+		}
+	}
+	return time.Sunday, false
+}
+
+func truncateDay(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.Local)
+}
@@ -0,0 +1,138 @@
+package calendar
+
+import (
+	"testing"
+	"time"
+
+	"github.com/anton1ks96/college-app-core/internal/domain"
+)
+
+func testCalendar(t *testing.T, years []domain.CalendarPeriod) *Calendar {
+	t.Helper()
+
+	cal, err := New(domain.AcademicCalendar{
+		Years: years,
+		Terms: []domain.CalendarPeriod{
+			{Name: "autumn", Start: "2026-09-01", End: "2026-12-29"},
+			{Name: "spring", Start: "2027-01-11", End: "2027-06-30"},
+		},
+		Holidays: []domain.CalendarPeriod{
+			{Name: "Unity Day", Start: "2026-11-04", End: "2026-11-04"},
+		},
+		Exams: []domain.CalendarPeriod{
+			{Name: "Winter exams", Start: "2026-12-21", End: "2026-12-29"},
+		},
+		Practices: []domain.CalendarPeriod{
+			{Name: "Practice", Start: "2027-05-17", End: "2027-06-05"},
+		},
+		Transfers: []domain.CalendarTransfer{
+			{From: "2026-11-08", To: "2026-11-09"},
+		},
+	})
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+	return cal
+}
+
+func date(t *testing.T, value string) time.Time {
+	t.Helper()
+
+	d, err := time.ParseInLocation(dateLayout, value, time.Local)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return d
+}
+
+func TestCalendarDayKinds(t *testing.T) {
+	cal := testCalendar(t, nil)
+
+	tests := []struct {
+		day      string
+		kind     domain.DayKind
+		name     string
+		studyDay bool
+	}{
+		{day: "2026-09-01", kind: domain.DayKindStudy, name: "autumn", studyDay: true},
+		{day: "2026-09-06", kind: domain.DayKindWeekend},
+		{day: "2026-09-05", kind: domain.DayKindStudy, name: "autumn", studyDay: true},
+		{day: "2026-11-04", kind: domain.DayKindHoliday, name: "Unity Day"},
+		{day: "2026-11-08", kind: domain.DayKindStudy, name: "autumn", studyDay: true},
+		{day: "2026-11-09", kind: domain.DayKindHoliday, name: "transferred day off"},
+		{day: "2026-12-22", kind: domain.DayKindExams, name: "Winter exams"},
+		{day: "2027-01-05", kind: domain.DayKindVacation},
+		{day: "2027-05-18", kind: domain.DayKindPractice, name: "Practice"},
+		{day: "2027-08-02", kind: domain.DayKindStudy, studyDay: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.day, func(t *testing.T) {
+			got := cal.Day(date(t, tt.day))
+			if got.Kind != tt.kind || got.Name != tt.name {
+				t.Errorf("Day(%s) = %s %q, want %s %q", tt.day, got.Kind, got.Name, tt.kind, tt.name)
+			}
+			if cal.IsStudyDay(date(t, tt.day)) != tt.studyDay {
+				t.Errorf("IsStudyDay(%s) = %v, want %v", tt.day, !tt.studyDay, tt.studyDay)
+			}
+		})
+	}
+}
+
+func TestCalendarYearAt(t *testing.T) {
+	tests := []struct {
+		name  string
+		years []domain.CalendarPeriod
+		day   string
+		want  domain.CalendarPeriod
+		found bool
+	}{
+		{
+			name:  "derived from terms",
+			day:   "2027-01-05",
+			want:  domain.CalendarPeriod{Start: "2026-09-01", End: "2027-06-30"},
+			found: true,
+		},
+		{
+			name:  "outside derived year",
+			day:   "2027-07-15",
+			found: false,
+		},
+		{
+			name:  "configured year",
+			years: []domain.CalendarPeriod{{Name: "2026/2027", Start: "2026-08-25", End: "2027-07-31"}},
+			day:   "2027-07-15",
+			want:  domain.CalendarPeriod{Name: "2026/2027", Start: "2026-08-25", End: "2027-07-31"},
+			found: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cal := testCalendar(t, tt.years)
+			got, found := cal.YearAt(date(t, tt.day))
+			if found != tt.found || got != tt.want {
+				t.Errorf("YearAt(%s) = %+v, %v; want %+v, %v", tt.day, got, found, tt.want, tt.found)
+			}
+		})
+	}
+}
+
+func TestNewRejectsInvalidPeriods(t *testing.T) {
+	tests := []struct {
+		name string
+		src  domain.AcademicCalendar
+	}{
+		{name: "malformed term date", src: domain.AcademicCalendar{Terms: []domain.CalendarPeriod{{Name: "autumn", Start: "01.09.2026", End: "2026-12-29"}}}},
+		{name: "term ends before start", src: domain.AcademicCalendar{Terms: []domain.CalendarPeriod{{Name: "autumn", Start: "2026-12-29", End: "2026-09-01"}}}},
+		{name: "unknown weekend", src: domain.AcademicCalendar{Weekends: []string{"funday"}}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := New(tt.src); err == nil {
+				t.Fatal("New accepted an invalid calendar")
+			}
+		})
+	}
+}
@@ -11,9 +11,20 @@ import (
 
 type (
 	Config struct {
-		Server Server
-		Portal Portal
-		Auth   Auth
+		Server        Server
+		Portal        Portal
+		Auth          Auth
+		Schedule      Schedule
+		Calendar      Calendar
+		Bells         Bells
+		Attendance    Attendance
+		Roster        Roster
+		Performance   Performance
+		Grading       Grading
+		Notifications Notifications
+		Storage       Storage
+		ClassDetails  ClassDetails
+		Homework      Homework
 	}
 
 	Server struct {
@@ -35,6 +46,105 @@ type (
 		ServiceURL string
 		Timeout    time.Duration
 	}
+
+	Schedule struct {
+		MaxGroups int
+		Workers   int
+	}
+
+	Calendar struct {
+		File     string
+		Timezone string
+	}
+
+	Attendance struct {
+		Statuses        map[string]string
+		ExcusedAsMissed bool
+		Budget          AttendanceBudget
+	}
+
+	AttendanceBudget struct {
+		Threshold float64
+		Subjects  []SubjectThreshold
+	}
+
+	SubjectThreshold struct {
+		Title     string
+		Threshold float64
+	}
+
+	Roster struct {
+		File         string
+		StaffRoles   []string
+		CuratorRoles []string
+		Workers      int
+		RateLimit    int
+	}
+
+	Performance struct {
+		Workers int
+	}
+
+	Grading struct {
+		Scale        float64
+		Marks        []GradeMark
+		TrendWindow  int
+		SlopeEntries int
+	}
+
+	GradeMark struct {
+		Mark int
+		Min  float64
+	}
+
+	Notifications struct {
+		Enabled    bool
+		Interval   time.Duration
+		Workers    int
+		Sink       string
+		WebhookURL string
+		Timeout    time.Duration
+		MailFrom   string
+		MailDomain string
+	}
+
+	Storage struct {
+		Path              string
+		Timeout           time.Duration
+		QueueSize         int
+		SnapshotRetention time.Duration
+	}
+
+	ClassDetails struct {
+		Workers  int
+		CacheTTL time.Duration
+		MaxBatch int
+	}
+
+	Homework struct {
+		Workers        int
+		MaxDays        int
+		TextKeys       []string
+		AttachmentKeys []string
+	}
+
+	Bells struct {
+		Periods    []BellPeriod
+		Alternates []BellAlternate
+	}
+
+	BellPeriod struct {
+		Number int
+		Start  string
+		End    string
+	}
+
+	BellAlternate struct {
+		Name     string
+		Weekdays []string
+		Dates    []string
+		Periods  []BellPeriod
+	}
 )
 
 func Init() (*Config, error) {
@@ -68,6 +178,7 @@ func parseConfigFile(folder string) error {
 	viper.BindEnv("portal.attendanceurl", "PORTAL_ATTENDANCE_URL")
 	viper.BindEnv("portal.performancesubjectsurl", "PORTAL_PERFORMANCE_SUBJECTS_URL")
 	viper.BindEnv("portal.performancescoreurl", "PORTAL_PERFORMANCE_SCORE_URL")
+	viper.BindEnv("notifications.webhookurl", "NOTIFICATIONS_WEBHOOK_URL")
 
 	return viper.ReadInConfig()
 }
@@ -1,5 +1,7 @@
 package domain
 
+import "time"
+
 type User struct {
 	ID            string `json:"id"`
 	Username      string `json:"username"`
@@ -29,6 +31,14 @@ type ScheduleEvent struct {
 	Color    string     `json:"color"`
 	Title    string     `json:"title"`
 	SubGroup []SubGroup `json:"SubGroup,omitempty"`
+	Conflict bool       `json:"conflict,omitempty"`
+	Period   int        `json:"period,omitempty"`
+
+	SourceGroup   string         `json:"source_group,omitempty"`
+	SubGroupID    string         `json:"subgroup_id,omitempty"`
+	OriginalTitle string         `json:"original_title,omitempty"`
+	Source        ScheduleSource `json:"source,omitempty"`
+	Notes         []LessonNote   `json:"notes,omitempty"`
 }
 
 type ScheduleRequest struct {
@@ -38,8 +48,35 @@ type ScheduleRequest struct {
 	Subgroup string `json:"subgroup"`
 }
 
+type ScheduleSelection struct {
+	Group           string `json:"group"`
+	Subgroup        string `json:"subgroup"`
+	EnglishGroup    string `json:"english_group"`
+	ProfileSubgroup string `json:"profile_subgroup"`
+}
+
 type ScheduleResponse struct {
 	Events []ScheduleEvent `json:"events"`
+	Days   []CalendarDay   `json:"days,omitempty"`
+}
+
+type ScheduleConflictEntry struct {
+	ClID  string `json:"ClID"`
+	SGrID string `json:"SGrID,omitempty"`
+	Title string `json:"title"`
+	Start string `json:"start"`
+	End   string `json:"end"`
+	Room  string `json:"room"`
+}
+
+type ScheduleConflict struct {
+	Day    string                `json:"Day"`
+	First  ScheduleConflictEntry `json:"first"`
+	Second ScheduleConflictEntry `json:"second"`
+}
+
+type ScheduleConflictsResponse struct {
+	Conflicts []ScheduleConflict `json:"conflicts"`
 }
 
 type AttendanceRequest struct {
@@ -54,18 +91,153 @@ type AttendanceSubGroup struct {
 	STitle string `json:"STitle"`
 }
 
+type AttendanceStatus string
+
+const (
+	AttendanceStatusPresent   AttendanceStatus = "present"
+	AttendanceStatusAbsent    AttendanceStatus = "absent"
+	AttendanceStatusLate      AttendanceStatus = "late"
+	AttendanceStatusExcused   AttendanceStatus = "excused"
+	AttendanceStatusNotMarked AttendanceStatus = "not_marked"
+	AttendanceStatusUnknown   AttendanceStatus = "unknown"
+)
+
+func (s AttendanceStatus) Attended() bool {
+	return s == AttendanceStatusPresent || s == AttendanceStatusLate
+}
+
+func (s AttendanceStatus) Missed() bool {
+	return s == AttendanceStatusAbsent
+}
+
+func (s AttendanceStatus) Marked() bool {
+	return s.Attended() || s.Missed() || s == AttendanceStatusExcused
+}
+
 type AttendanceRecord struct {
-	ClID     int                  `json:"ClID"`
-	Day      string               `json:"Day"`
-	Topic    string               `json:"topic"`
-	Start    string               `json:"start"`
-	End      string               `json:"end"`
-	Room     string               `json:"room"`
-	Status   int                  `json:"status"`
-	Title    string               `json:"title"`
-	Color    string               `json:"color"`
-	Type     string               `json:"type,omitempty"`
-	SubGroup []AttendanceSubGroup `json:"SubGroup,omitempty"`
+	ClID       int                  `json:"ClID"`
+	Day        string               `json:"Day"`
+	Topic      string               `json:"topic"`
+	Start      string               `json:"start"`
+	End        string               `json:"end"`
+	Room       string               `json:"room"`
+	Status     int                  `json:"status"`
+	StatusName AttendanceStatus     `json:"status_name"`
+	Title      string               `json:"title"`
+	Color      string               `json:"color"`
+	Type       string               `json:"type,omitempty"`
+	SubGroup   []AttendanceSubGroup `json:"SubGroup,omitempty"`
+	Period     int                  `json:"period,omitempty"`
+
+	SubClID       int    `json:"sub_clid,omitempty"`
+	OriginalTitle string `json:"original_title,omitempty"`
+}
+
+type SubjectAttendanceStats struct {
+	Title          string         `json:"title"`
+	Total          int            `json:"total"`
+	Attended       int            `json:"attended"`
+	Missed         int            `json:"missed"`
+	ByStatus       map[string]int `json:"by_status"`
+	AttendanceRate float64        `json:"attendance_rate"`
+	HoursMissed    float64        `json:"hours_missed"`
+}
+
+type AttendanceStatsResponse struct {
+	PeriodStart string                   `json:"period_start"`
+	PeriodEnd   string                   `json:"period_end"`
+	Subjects    []SubjectAttendanceStats `json:"subjects"`
+}
+
+type HeatmapDay struct {
+	Date           string           `json:"date"`
+	Weekday        string           `json:"weekday"`
+	Kind           DayKind          `json:"kind"`
+	Name           string           `json:"name,omitempty"`
+	Scheduled      int              `json:"scheduled"`
+	Attended       int              `json:"attended"`
+	Missed         int              `json:"missed"`
+	DominantStatus AttendanceStatus `json:"dominant_status,omitempty"`
+	Intensity      float64          `json:"intensity"`
+}
+
+type AttendanceHeatmapResponse struct {
+	PeriodStart string       `json:"period_start"`
+	PeriodEnd   string       `json:"period_end"`
+	Days        []HeatmapDay `json:"days"`
+}
+
+type SubjectAbsenceBudget struct {
+	Title          string  `json:"title"`
+	Threshold      float64 `json:"threshold"`
+	Attended       int     `json:"attended"`
+	Missed         int     `json:"missed"`
+	Remaining      int     `json:"remaining"`
+	AttendanceRate float64 `json:"attendance_rate"`
+	CanMiss        int     `json:"can_miss"`
+	AtRisk         bool    `json:"at_risk"`
+	Unreachable    bool    `json:"unreachable"`
+}
+
+type AbsenceBudgetResponse struct {
+	PeriodStart string                 `json:"period_start"`
+	PeriodEnd   string                 `json:"period_end"`
+	Subjects    []SubjectAbsenceBudget `json:"subjects"`
+}
+
+type ReconciliationEntry struct {
+	ClID   string           `json:"ClID"`
+	Day    string           `json:"Day"`
+	Start  string           `json:"start"`
+	End    string           `json:"end"`
+	Title  string           `json:"title"`
+	Room   string           `json:"room"`
+	Status AttendanceStatus `json:"status,omitempty"`
+}
+
+type ReconciliationMismatch struct {
+	ClID      string `json:"ClID"`
+	Day       string `json:"Day"`
+	Field     string `json:"field"`
+	Scheduled string `json:"scheduled"`
+	Marked    string `json:"marked"`
+}
+
+type ReconciliationReport struct {
+	PeriodStart  string                   `json:"period_start"`
+	PeriodEnd    string                   `json:"period_end"`
+	MissingMarks []ReconciliationEntry    `json:"missing_marks"`
+	Unscheduled  []ReconciliationEntry    `json:"unscheduled"`
+	Mismatches   []ReconciliationMismatch `json:"mismatches"`
+}
+
+type RosterStudent struct {
+	Login string `json:"login"`
+	Name  string `json:"name"`
+}
+
+type GroupAttendanceCell struct {
+	Status   AttendanceStatus `json:"status,omitempty"`
+	Attended int              `json:"attended"`
+	Missed   int              `json:"missed"`
+}
+
+type GroupAttendanceRow struct {
+	Login          string                `json:"login"`
+	Name           string                `json:"name"`
+	Cells          []GroupAttendanceCell `json:"cells"`
+	Attended       int                   `json:"attended"`
+	Missed         int                   `json:"missed"`
+	AttendanceRate float64               `json:"attendance_rate"`
+	Error          string                `json:"error,omitempty"`
+}
+
+type GroupAttendanceReport struct {
+	Group       string               `json:"group"`
+	PeriodStart string               `json:"period_start"`
+	PeriodEnd   string               `json:"period_end"`
+	Days        []string             `json:"days"`
+	Students    []GroupAttendanceRow `json:"students"`
 }
 
 type PerformanceSubject struct {
@@ -88,13 +260,393 @@ type PerformanceScore struct {
 	Description string `json:"Description"`
 }
 
+// ScoreBook is the typed form of the portal's score payload for one subject
+// request. The portal answers with map[subject]map[workType][]PerformanceScore,
+// or a bare "[]" when there are no scores; both end up here as Subjects.
+type ScoreBook struct {
+	SuID        string             `json:"SuID"`
+	PeriodStart string             `json:"period_start"`
+	PeriodEnd   string             `json:"period_end"`
+	Subjects    []ScoreBookSubject `json:"subjects"`
+}
+
+// ScoreBookSubject is the outer key of the portal payload: the subject
+// (discipline) title the scores belong to, sorted by title.
+type ScoreBookSubject struct {
+	Title     string              `json:"title"`
+	WorkTypes []ScoreBookWorkType `json:"work_types"`
+}
+
+// ScoreBookWorkType is the inner key of the portal payload: the kind of work
+// (test, lab, exam...) and its entries ordered by DateP, then DateF.
+type ScoreBookWorkType struct {
+	Title   string             `json:"title"`
+	Entries []PerformanceScore `json:"entries"`
+}
+
+type ScoreKind string
+
+const (
+	ScoreKindNumeric ScoreKind = "numeric"
+	ScoreKindPass    ScoreKind = "pass"
+	ScoreKindFail    ScoreKind = "fail"
+	ScoreKindAbsent  ScoreKind = "absent"
+	ScoreKindExempt  ScoreKind = "exempt"
+	ScoreKindUnknown ScoreKind = "unknown"
+)
+
+type NormalizedScore struct {
+	Raw      string    `json:"raw"`
+	Kind     ScoreKind `json:"kind"`
+	Value    float64   `json:"value"`
+	MaxScore int       `json:"max_score"`
+	Ratio    float64   `json:"ratio"`
+}
+
+type SubjectPerformanceSummary struct {
+	SuID            string            `json:"SuID"`
+	Title           string            `json:"title"`
+	Scores          int               `json:"scores"`
+	NumericScores   int               `json:"numeric_scores"`
+	Marks           map[ScoreKind]int `json:"marks"`
+	Average         float64           `json:"average"`
+	WeightedAverage float64           `json:"weighted_average"`
+	Error           string            `json:"error,omitempty"`
+}
+
+type PerformanceSummaryResponse struct {
+	PeriodStart     string                      `json:"period_start"`
+	PeriodEnd       string                      `json:"period_end"`
+	Average         float64                     `json:"average"`
+	WeightedAverage float64                     `json:"weighted_average"`
+	Subjects        []SubjectPerformanceSummary `json:"subjects"`
+}
+
+type GradebookEntry struct {
+	Subject     string          `json:"subject"`
+	WorkType    string          `json:"work_type"`
+	DateF       string          `json:"DateF"`
+	DateP       string          `json:"DateP"`
+	Score       string          `json:"Score"`
+	MaxScore    int             `json:"MaxScore"`
+	Description string          `json:"Description"`
+	Normalized  NormalizedScore `json:"normalized"`
+}
+
+type GradebookSubject struct {
+	SuID    string           `json:"SuID"`
+	Title   string           `json:"title"`
+	Entries []GradebookEntry `json:"entries"`
+	Error   string           `json:"error,omitempty"`
+}
+
+type GradebookResponse struct {
+	PeriodStart string             `json:"period_start"`
+	PeriodEnd   string             `json:"period_end"`
+	Subjects    []GradebookSubject `json:"subjects"`
+	Failed      int                `json:"failed"`
+}
+
+type StudentReport struct {
+	Login       string                      `json:"login"`
+	PeriodStart string                      `json:"period_start"`
+	PeriodEnd   string                      `json:"period_end"`
+	GeneratedAt time.Time                   `json:"generated_at"`
+	Summary     *PerformanceSummaryResponse `json:"summary"`
+	Gradebook   *GradebookResponse          `json:"gradebook"`
+	Attendance  *AttendanceStatsResponse    `json:"attendance"`
+}
+
+type TrendPoint struct {
+	Date          string  `json:"date"`
+	WorkType      string  `json:"work_type"`
+	Description   string  `json:"Description"`
+	Score         string  `json:"Score"`
+	MaxScore      int     `json:"MaxScore"`
+	Value         float64 `json:"value"`
+	MovingAverage float64 `json:"moving_average"`
+}
+
+type GradeTrend struct {
+	SuID           string       `json:"SuID"`
+	PeriodStart    string       `json:"period_start"`
+	PeriodEnd      string       `json:"period_end"`
+	Scale          float64      `json:"scale"`
+	Window         int          `json:"window"`
+	SlopeEntries   int          `json:"slope_entries"`
+	Points         []TrendPoint `json:"points"`
+	Average        float64      `json:"average"`
+	Slope          float64      `json:"slope"`
+	CurrentMark    int          `json:"current_mark,omitempty"`
+	PredictedMark  int          `json:"predicted_mark,omitempty"`
+	PredictedValue float64      `json:"predicted_value"`
+}
+
+type WhatIfScore struct {
+	Value    float64 `json:"value"`
+	MaxScore int     `json:"MaxScore"`
+}
+
+type WhatIfResult struct {
+	SuID                   string  `json:"SuID"`
+	Scale                  float64 `json:"scale"`
+	RealScores             int     `json:"real_scores"`
+	HypotheticalScores     int     `json:"hypothetical_scores"`
+	CurrentAverage         float64 `json:"current_average"`
+	CurrentWeightedAverage float64 `json:"current_weighted_average"`
+	CurrentMark            int     `json:"current_mark,omitempty"`
+	Average                float64 `json:"average"`
+	WeightedAverage        float64 `json:"weighted_average"`
+	Mark                   int     `json:"mark,omitempty"`
+}
+
+type GradeNotificationKind string
+
+const (
+	GradeNotificationNew     GradeNotificationKind = "new"
+	GradeNotificationChanged GradeNotificationKind = "changed"
+)
+
+type GradeNotification struct {
+	Kind          GradeNotificationKind `json:"kind"`
+	Login         string                `json:"login"`
+	SuID          string                `json:"SuID"`
+	Subject       string                `json:"subject"`
+	WorkType      string                `json:"work_type"`
+	DateF         string                `json:"DateF"`
+	DateP         string                `json:"DateP"`
+	Description   string                `json:"Description"`
+	Score         string                `json:"Score"`
+	MaxScore      int                   `json:"MaxScore"`
+	PreviousScore string                `json:"previous_score,omitempty"`
+	DetectedAt    time.Time             `json:"detected_at"`
+}
+
+type StreakMode string
+
+const (
+	StreakModeDaily  StreakMode = "daily"
+	StreakModeWeekly StreakMode = "weekly"
+)
+
+type StreakOptions struct {
+	Mode               StreakMode
+	ExcusedKeepsStreak bool
+	BySubject          bool
+	Start              string
+	End                string
+}
+
 type StreakResponse struct {
+	Mode              StreakMode      `json:"mode"`
+	CurrentStreak     int             `json:"current_streak"`
+	LongestStreak     int             `json:"longest_streak"`
+	TotalDaysAttended int             `json:"total_days_attended"`
+	TotalSchoolDays   int             `json:"total_school_days"`
+	AttendanceRate    float64         `json:"attendance_rate"`
+	LastAttendedDate  string          `json:"last_attended_date,omitempty"`
+	WeeksAttended     int             `json:"weeks_attended,omitempty"`
+	TotalWeeks        int             `json:"total_weeks,omitempty"`
+	LastAttendedWeek  string          `json:"last_attended_week,omitempty"`
+	PeriodStart       string          `json:"period_start"`
+	PeriodEnd         string          `json:"period_end"`
+	Subjects          []SubjectStreak `json:"subjects,omitempty"`
+}
+
+type SubjectStreak struct {
+	Title             string  `json:"title"`
 	CurrentStreak     int     `json:"current_streak"`
 	LongestStreak     int     `json:"longest_streak"`
 	TotalDaysAttended int     `json:"total_days_attended"`
 	TotalSchoolDays   int     `json:"total_school_days"`
 	AttendanceRate    float64 `json:"attendance_rate"`
 	LastAttendedDate  string  `json:"last_attended_date,omitempty"`
-	PeriodStart       string  `json:"period_start"`
-	PeriodEnd         string  `json:"period_end"`
+	WeeksAttended     int     `json:"weeks_attended,omitempty"`
+	TotalWeeks        int     `json:"total_weeks,omitempty"`
+	LastAttendedWeek  string  `json:"last_attended_week,omitempty"`
+}
+
+type DayKind string
+
+const (
+	DayKindStudy    DayKind = "study"
+	DayKindWeekend  DayKind = "weekend"
+	DayKindHoliday  DayKind = "holiday"
+	DayKindExams    DayKind = "exams"
+	DayKindPractice DayKind = "practice"
+	DayKindVacation DayKind = "vacation"
+)
+
+type CalendarPeriod struct {
+	Name  string `json:"name"`
+	Start string `json:"start"`
+	End   string `json:"end"`
+}
+
+type CalendarTransfer struct {
+	From string `json:"from"`
+	To   string `json:"to"`
+}
+
+type CalendarDay struct {
+	Date    string  `json:"date"`
+	Weekday string  `json:"weekday"`
+	Kind    DayKind `json:"kind"`
+	Name    string  `json:"name,omitempty"`
+}
+
+type AcademicCalendar struct {
+	Years     []CalendarPeriod   `json:"years,omitempty"`
+	Terms     []CalendarPeriod   `json:"terms"`
+	Holidays  []CalendarPeriod   `json:"holidays"`
+	Exams     []CalendarPeriod   `json:"exams"`
+	Practices []CalendarPeriod   `json:"practices"`
+	Transfers []CalendarTransfer `json:"transfers"`
+	Weekends  []string           `json:"weekends"`
+	Days      []CalendarDay      `json:"days,omitempty"`
+}
+
+type BellPeriod struct {
+	Number int    `json:"period"`
+	Start  string `json:"start"`
+	End    string `json:"end"`
+}
+
+type BellSchedule struct {
+	Date     string       `json:"date"`
+	Schedule string       `json:"schedule"`
+	Periods  []BellPeriod `json:"periods"`
+}
+
+type ScheduleSnapshot struct {
+	Group   string          `json:"group"`
+	Start   string          `json:"start"`
+	End     string          `json:"end"`
+	Events  []ScheduleEvent `json:"events"`
+	SavedAt time.Time       `json:"saved_at"`
+}
+
+type AttendanceChange struct {
+	ClID           int              `json:"ClID"`
+	Day            string           `json:"Day"`
+	Start          string           `json:"start"`
+	Title          string           `json:"title"`
+	Status         AttendanceStatus `json:"status"`
+	PreviousStatus AttendanceStatus `json:"previous_status"`
+	ObservedAt     time.Time        `json:"observed_at"`
+}
+
+type ScoreChange struct {
+	SuID          string    `json:"SuID"`
+	Subject       string    `json:"subject"`
+	WorkType      string    `json:"work_type"`
+	DateF         string    `json:"DateF"`
+	DateP         string    `json:"DateP"`
+	Description   string    `json:"Description"`
+	Score         string    `json:"Score"`
+	MaxScore      int       `json:"MaxScore"`
+	PreviousScore string    `json:"previous_score,omitempty"`
+	ObservedAt    time.Time `json:"observed_at"`
+}
+
+type AttendanceHistoryResponse struct {
+	PeriodStart string             `json:"period_start"`
+	PeriodEnd   string             `json:"period_end"`
+	Changes     []AttendanceChange `json:"changes"`
+}
+
+type ScoreHistoryResponse struct {
+	PeriodStart string        `json:"period_start"`
+	PeriodEnd   string        `json:"period_end"`
+	Changes     []ScoreChange `json:"changes"`
+}
+
+type NotificationPreferences struct {
+	Grades *bool `json:"grades,omitempty"`
+}
+
+type UserPreferences struct {
+	Group           string                  `json:"group"`
+	Subgroup        string                  `json:"subgroup"`
+	EnglishGroup    string                  `json:"english_group"`
+	ProfileSubgroup string                  `json:"profile_subgroup"`
+	Timezone        string                  `json:"timezone"`
+	Notifications   NotificationPreferences `json:"notifications"`
+	HiddenSubjects  []string                `json:"hidden_subjects"`
+	UpdatedAt       time.Time               `json:"updated_at"`
+}
+
+type SubjectCustomization struct {
+	Title string `json:"title"`
+	Alias string `json:"alias,omitempty"`
+	Color string `json:"color,omitempty"`
+}
+
+type ScheduleCustomization struct {
+	Subjects        []SubjectCustomization `json:"subjects"`
+	HiddenSubgroups []string               `json:"hidden_subgroups"`
+	HiddenEvents    []string               `json:"hidden_events"`
+	UpdatedAt       time.Time              `json:"updated_at"`
+}
+
+type ScheduleSource string
+
+const (
+	ScheduleSourcePortal   ScheduleSource = "portal"
+	ScheduleSourcePersonal ScheduleSource = "personal"
+)
+
+type LessonNote struct {
+	ID        string    `json:"id"`
+	ClID      string    `json:"ClID"`
+	Text      string    `json:"text"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+type PersonalEvent struct {
+	ID          string    `json:"id"`
+	Title       string    `json:"title"`
+	Day         string    `json:"Day"`
+	Start       string    `json:"start"`
+	End         string    `json:"end"`
+	Room        string    `json:"room"`
+	Description string    `json:"description"`
+	Color       string    `json:"color"`
+	CreatedAt   time.Time `json:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at"`
+}
+
+type ClassDetailsResult struct {
+	Details map[string]any `json:"details,omitempty"`
+	Error   string         `json:"error,omitempty"`
+}
+
+type ClassDetailsBatchResponse struct {
+	Items  map[string]ClassDetailsResult `json:"items"`
+	Failed int                           `json:"failed"`
+}
+
+type HomeworkAttachment struct {
+	Name string `json:"name"`
+	URL  string `json:"url"`
+}
+
+type HomeworkItem struct {
+	ClID        string               `json:"ClID"`
+	Day         string               `json:"Day"`
+	Start       string               `json:"start"`
+	End         string               `json:"end"`
+	Title       string               `json:"title"`
+	Period      int                  `json:"period,omitempty"`
+	Text        string               `json:"text"`
+	Attachments []HomeworkAttachment `json:"attachments"`
+	Error       string               `json:"error,omitempty"`
+}
+
+type HomeworkResponse struct {
+	PeriodStart string         `json:"period_start"`
+	PeriodEnd   string         `json:"period_end"`
+	Items       []HomeworkItem `json:"items"`
+	Failed      int            `json:"failed"`
 }
@@ -3,16 +3,33 @@ package handlers
 import (
 	"github.com/anton1ks96/college-app-core/internal/config"
 	v1 "github.com/anton1ks96/college-app-core/internal/handlers/v1"
+	v2 "github.com/anton1ks96/college-app-core/internal/handlers/v2"
+	"github.com/anton1ks96/college-app-core/internal/httpmw"
+	"github.com/anton1ks96/college-app-core/internal/repository"
+	"github.com/anton1ks96/college-app-core/internal/services"
+	"github.com/anton1ks96/college-app-core/internal/storage"
 	"github.com/gin-gonic/gin"
 )
 
 type Handler struct {
-	cfg *config.Config
+	cfg         *config.Config
+	store       storage.Storage
+	history     *services.HistoryRecorder
+	portal      *repository.PortalRepository
+	calendar    *services.CalendarService
+	performance *services.PerformanceService
+	auth        *httpmw.AuthMiddleware
 }
 
-func NewHandler(cfg *config.Config) *Handler {
+func NewHandler(cfg *config.Config, store storage.Storage, history *services.HistoryRecorder, portal *repository.PortalRepository, calendar *services.CalendarService, performance *services.PerformanceService, auth *httpmw.AuthMiddleware) *Handler {
 	return &Handler{
-		cfg: cfg,
+		cfg:         cfg,
+		store:       store,
+		history:     history,
+		portal:      portal,
+		calendar:    calendar,
+		performance: performance,
+		auth:        auth,
 	}
 }
 
@@ -35,10 +52,15 @@ func (h *Handler) Init() *gin.Engine {
 func (h *Handler) initAPI(router *gin.Engine) {
 	api := router.Group("/api")
 
-	v1Handler := v1.NewHandler(h.cfg)
+	v1Handler := v1.NewHandler(h.cfg, h.store, h.history, h.portal, h.calendar, h.performance, h.auth)
 	v1Group := api.Group("/v1")
 
 	v1Handler.Init(v1Group)
+
+	v2Handler := v2.NewHandler(h.cfg, h.performance, h.auth)
+	v2Group := api.Group("/v2")
+
+	v2Handler.Init(v2Group)
 }
 
 func (h *Handler) healthCheck(c *gin.Context) {
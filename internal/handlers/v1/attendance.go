@@ -1,8 +1,12 @@
 package v1
 
 import (
+	"fmt"
 	"net/http"
+	"strconv"
+	"time"
 
+	"github.com/anton1ks96/college-app-core/internal/domain"
 	"github.com/anton1ks96/college-app-core/internal/httpmw"
 	"github.com/anton1ks96/college-app-core/internal/services"
 	"github.com/anton1ks96/college-app-core/pkg/logger"
@@ -10,12 +14,16 @@ import (
 )
 
 type AttendanceHandler struct {
-	attendanceService *services.AttendanceService
+	attendanceService    *services.AttendanceService
+	customizationService *services.CustomizationService
+	preferencesService   *services.PreferencesService
 }
 
-func NewAttendanceHandler(svc *services.AttendanceService) *AttendanceHandler {
+func NewAttendanceHandler(svc *services.AttendanceService, customization *services.CustomizationService, preferences *services.PreferencesService) *AttendanceHandler {
 	return &AttendanceHandler{
-		attendanceService: svc,
+		attendanceService:    svc,
+		customizationService: customization,
+		preferencesService:   preferences,
 	}
 }
 
@@ -42,13 +50,125 @@ func (h *AttendanceHandler) GetAttendance(c *gin.Context) {
 		return
 	}
 
-	c.JSON(http.StatusOK, records)
+	customized, err := h.customizationService.CustomizeAttendance(login, start, end, records)
+	if err != nil {
+		logger.Logger.Error().
+			Err(err).
+			Str("login", login).
+			Msg("failed to apply attendance customization")
+		customized = records
+	}
+
+	c.JSON(http.StatusOK, customized)
+}
+
+func (h *AttendanceHandler) GetAttendanceStats(c *gin.Context) {
+	start := c.Query("start")
+	end := c.Query("end")
+
+	if start == "" || end == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "missing required query params: start and end"})
+		return
+	}
+
+	login, _ := httpmw.GetUserID(c)
+
+	stats, err := h.attendanceService.GetAttendanceStats(login, start, end)
+	if err != nil {
+		logger.Logger.Error().
+			Err(err).
+			Str("login", login).
+			Str("start", start).
+			Str("end", end).
+			Msg("failed to get attendance stats")
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, stats)
+}
+
+func (h *AttendanceHandler) GetAttendanceHeatmap(c *gin.Context) {
+	start := c.Query("start")
+	end := c.Query("end")
+	sel, _ := resolveSelection(c, h.preferencesService)
+
+	if start == "" || end == "" || sel.Group == "" || sel.Subgroup == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "missing required query params: start, end, group and subgroup"})
+		return
+	}
+
+	login, _ := httpmw.GetUserID(c)
+
+	heatmap, err := h.attendanceService.GetAttendanceHeatmap(login, sel, start, end)
+	if err != nil {
+		logger.Logger.Error().
+			Err(err).
+			Str("login", login).
+			Str("start", start).
+			Str("end", end).
+			Msg("failed to get attendance heatmap")
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, heatmap)
+}
+
+func (h *AttendanceHandler) GetAbsenceBudget(c *gin.Context) {
+	sel, _ := resolveSelection(c, h.preferencesService)
+
+	if sel.Group == "" || sel.Subgroup == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "missing required query params: group and subgroup"})
+		return
+	}
+
+	login, _ := httpmw.GetUserID(c)
+
+	budget, err := h.attendanceService.GetAbsenceBudget(login, sel)
+	if err != nil {
+		logger.Logger.Error().
+			Err(err).
+			Str("login", login).
+			Str("group", sel.Group).
+			Msg("failed to get absence budget")
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, budget)
 }
 
 func (h *AttendanceHandler) GetAttendanceStreak(c *gin.Context) {
+	opts := domain.StreakOptions{
+		Mode:  domain.StreakMode(c.DefaultQuery("mode", string(domain.StreakModeDaily))),
+		Start: c.Query("start"),
+		End:   c.Query("end"),
+	}
+
+	if opts.Mode != domain.StreakModeDaily && opts.Mode != domain.StreakModeWeekly {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid mode: expected daily or weekly"})
+		return
+	}
+
+	if err := validateOptionalRange(opts.Start, opts.End); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	var err error
+	if opts.ExcusedKeepsStreak, err = parseBoolQuery(c, "excused"); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if opts.BySubject, err = parseBoolQuery(c, "by_subject"); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
 	login, _ := httpmw.GetUserID(c)
 
-	streak, err := h.attendanceService.GetAttendanceStreak(login)
+	streak, err := h.attendanceService.GetAttendanceStreak(login, opts)
 	if err != nil {
 		logger.Logger.Error().
 			Err(err).
@@ -60,3 +180,35 @@ func (h *AttendanceHandler) GetAttendanceStreak(c *gin.Context) {
 
 	c.JSON(http.StatusOK, streak)
 }
+
+func validateOptionalRange(start, end string) error {
+	var from, to time.Time
+	var err error
+	if start != "" {
+		if from, err = time.Parse("2006-01-02", start); err != nil {
+			return fmt.Errorf("invalid start date: expected YYYY-MM-DD")
+		}
+	}
+	if end != "" {
+		if to, err = time.Parse("2006-01-02", end); err != nil {
+			return fmt.Errorf("invalid end date: expected YYYY-MM-DD")
+		}
+	}
+	if start != "" && end != "" && to.Before(from) {
+		return fmt.Errorf("end date must not be before start date")
+	}
+	return nil
+}
+
+func parseBoolQuery(c *gin.Context, key string) (bool, error) {
+	value := c.Query(key)
+	if value == "" {
+		return false, nil
+	}
+
+	parsed, err := strconv.ParseBool(value)
+	if err != nil {
+		return false, fmt.Errorf("invalid %s: expected true or false", key)
+	}
+	return parsed, nil
+}
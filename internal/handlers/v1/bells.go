@@ -0,0 +1,31 @@
+package v1
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/anton1ks96/college-app-core/internal/services"
+	"github.com/gin-gonic/gin"
+)
+
+type BellHandler struct {
+	bellService *services.BellService
+}
+
+func NewBellHandler(svc *services.BellService) *BellHandler {
+	return &BellHandler{
+		bellService: svc,
+	}
+}
+
+func (h *BellHandler) GetBells(c *gin.Context) {
+	date := c.DefaultQuery("date", time.Now().Format("2006-01-02"))
+
+	bells, err := h.bellService.GetBells(date)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, bells)
+}
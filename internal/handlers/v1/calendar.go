@@ -0,0 +1,36 @@
+package v1
+
+import (
+	"net/http"
+
+	"github.com/anton1ks96/college-app-core/internal/services"
+	"github.com/gin-gonic/gin"
+)
+
+type CalendarHandler struct {
+	calendarService *services.CalendarService
+}
+
+func NewCalendarHandler(svc *services.CalendarService) *CalendarHandler {
+	return &CalendarHandler{
+		calendarService: svc,
+	}
+}
+
+func (h *CalendarHandler) GetCalendar(c *gin.Context) {
+	start := c.Query("start")
+	end := c.Query("end")
+
+	if (start == "") != (end == "") {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "start and end must be provided together"})
+		return
+	}
+
+	cal, err := h.calendarService.GetCalendar(start, end)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, cal)
+}
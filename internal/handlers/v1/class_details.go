@@ -0,0 +1,68 @@
+package v1
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/anton1ks96/college-app-core/internal/services"
+	"github.com/gin-gonic/gin"
+)
+
+type ClassDetailsHandler struct {
+	classDetailsService *services.ClassDetailsService
+	maxBatch            int
+}
+
+func NewClassDetailsHandler(svc *services.ClassDetailsService, maxBatch int) *ClassDetailsHandler {
+	return &ClassDetailsHandler{
+		classDetailsService: svc,
+		maxBatch:            maxBatch,
+	}
+}
+
+type classDetailsBatchRequest struct {
+	IDs []string `json:"ids"`
+}
+
+func (h *ClassDetailsHandler) GetClassDetails(c *gin.Context) {
+	clid := c.Query("id")
+	if clid == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "missing id"})
+		return
+	}
+
+	details, err := h.classDetailsService.GetClassDetails(clid)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, details)
+}
+
+func (h *ClassDetailsHandler) GetClassDetailsBatch(c *gin.Context) {
+	var req classDetailsBatchRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid request body"})
+		return
+	}
+
+	if len(req.IDs) == 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "missing required field: ids"})
+		return
+	}
+
+	if h.maxBatch > 0 && len(req.IDs) > h.maxBatch {
+		c.JSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("too many ids: at most %d allowed", h.maxBatch)})
+		return
+	}
+
+	for i, id := range req.IDs {
+		if id == "" {
+			c.JSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("invalid id %d: must not be empty", i+1)})
+			return
+		}
+	}
+
+	c.JSON(http.StatusOK, h.classDetailsService.GetClassDetailsBatch(req.IDs))
+}
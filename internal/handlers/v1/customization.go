@@ -0,0 +1,75 @@
+package v1
+
+import (
+	"fmt"
+	"net/http"
+	"regexp"
+	"strings"
+
+	"github.com/anton1ks96/college-app-core/internal/domain"
+	"github.com/anton1ks96/college-app-core/internal/httpmw"
+	"github.com/anton1ks96/college-app-core/internal/services"
+	"github.com/anton1ks96/college-app-core/pkg/logger"
+	"github.com/gin-gonic/gin"
+)
+
+var colorRe = regexp.MustCompile(`^#([0-9a-fA-F]{3}|[0-9a-fA-F]{6})$`)
+
+type CustomizationHandler struct {
+	customizationService *services.CustomizationService
+}
+
+func NewCustomizationHandler(svc *services.CustomizationService) *CustomizationHandler {
+	return &CustomizationHandler{
+		customizationService: svc,
+	}
+}
+
+func (h *CustomizationHandler) GetCustomization(c *gin.Context) {
+	login, _ := httpmw.GetUserID(c)
+
+	customization, err := h.customizationService.GetCustomization(login)
+	if err != nil {
+		logger.Logger.Error().
+			Err(err).
+			Str("login", login).
+			Msg("failed to get customization")
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, customization)
+}
+
+func (h *CustomizationHandler) UpdateCustomization(c *gin.Context) {
+	var req domain.ScheduleCustomization
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid request body"})
+		return
+	}
+
+	for i, subject := range req.Subjects {
+		if strings.TrimSpace(subject.Title) == "" {
+			c.JSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("invalid subject %d: title is required", i+1)})
+			return
+		}
+		if subject.Color != "" && !colorRe.MatchString(subject.Color) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("invalid subject %d: color must be a hex value like #1e88e5", i+1)})
+			return
+		}
+	}
+
+	login, _ := httpmw.GetUserID(c)
+
+	customization, err := h.customizationService.UpdateCustomization(login, req)
+	if err != nil {
+		logger.Logger.Error().
+			Err(err).
+			Str("login", login).
+			Msg("failed to update customization")
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, customization)
+}
@@ -0,0 +1,96 @@
+package v1
+
+import (
+	"bytes"
+	"encoding/csv"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/anton1ks96/college-app-core/pkg/ical"
+	"github.com/anton1ks96/college-app-core/pkg/pdf"
+	"github.com/anton1ks96/college-app-core/pkg/xlsx"
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	csvContentType  = "text/csv; charset=utf-8"
+	xlsxContentType = "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet"
+	pdfContentType  = "application/pdf"
+	icalContentType = "text/calendar; charset=utf-8"
+)
+
+func writeCSV(c *gin.Context, filename string, rows [][]any) {
+	var buf bytes.Buffer
+	buf.WriteString("\ufeff")
+
+	w := csv.NewWriter(&buf)
+	for _, row := range rows {
+		record := make([]string, len(row))
+		for i, value := range row {
+			record[i] = formatCell(value)
+		}
+		if err := w.Write(record); err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+	}
+	w.Flush()
+	if err := w.Error(); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.Header("Content-Disposition", fmt.Sprintf("attachment; filename=%q", filename+".csv"))
+	c.Data(http.StatusOK, csvContentType, buf.Bytes())
+}
+
+func writeXLSX(c *gin.Context, filename, sheet string, rows [][]any) {
+	var buf bytes.Buffer
+	if err := xlsx.Write(&buf, sheet, rows); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.Header("Content-Disposition", fmt.Sprintf("attachment; filename=%q", filename+".xlsx"))
+	c.Data(http.StatusOK, xlsxContentType, buf.Bytes())
+}
+
+func writePDF(c *gin.Context, filename string, doc *pdf.Document) {
+	var buf bytes.Buffer
+	if err := doc.Write(&buf); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.Header("Content-Disposition", fmt.Sprintf("attachment; filename=%q", filename+".pdf"))
+	c.Data(http.StatusOK, pdfContentType, buf.Bytes())
+}
+
+func writeICal(c *gin.Context, filename, name string, events []ical.Event) {
+	var buf bytes.Buffer
+	if err := ical.Write(&buf, name, events); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.Header("Content-Disposition", fmt.Sprintf("attachment; filename=%q", filename+".ics"))
+	c.Data(http.StatusOK, icalContentType, buf.Bytes())
+}
+
+func formatCell(value any) string {
+	switch v := value.(type) {
+	case nil:
+		return ""
+	case string:
+		if v != "" && strings.ContainsRune("=+-@\t\r", rune(v[0])) {
+			return "'" + v
+		}
+		return v
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	default:
+		return fmt.Sprint(v)
+	}
+}
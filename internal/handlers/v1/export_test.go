@@ -0,0 +1,34 @@
+package v1
+
+import "testing"
+
+func TestFormatCell(t *testing.T) {
+	tests := []struct {
+		name  string
+		value any
+		want  string
+	}{
+		{name: "nil", value: nil, want: ""},
+		{name: "plain text", value: "Математика", want: "Математика"},
+		{name: "empty string", value: "", want: ""},
+		{name: "formula", value: "=HYPERLINK(\"http://x\")", want: "'=HYPERLINK(\"http://x\")"},
+		{name: "plus", value: "+1", want: "'+1"},
+		{name: "minus", value: "-2", want: "'-2"},
+		{name: "at sign", value: "@SUM(A1)", want: "'@SUM(A1)"},
+		{name: "tab", value: "\t=1", want: "'\t=1"},
+		{name: "carriage return", value: "\r=1", want: "'\r=1"},
+		{name: "formula char later", value: "a=b", want: "a=b"},
+		{name: "float", value: 4.25, want: "4.25"},
+		{name: "negative float", value: -1.5, want: "-1.5"},
+		{name: "int", value: 7, want: "7"},
+		{name: "bool", value: true, want: "true"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := formatCell(tt.value); got != tt.want {
+				t.Errorf("formatCell(%#v) = %q, want %q", tt.value, got, tt.want)
+			}
+		})
+	}
+}
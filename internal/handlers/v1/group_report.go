@@ -0,0 +1,80 @@
+package v1
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/anton1ks96/college-app-core/internal/httpmw"
+	"github.com/anton1ks96/college-app-core/internal/services"
+	"github.com/anton1ks96/college-app-core/pkg/logger"
+	"github.com/gin-gonic/gin"
+)
+
+type GroupReportHandler struct {
+	groupReportService *services.GroupReportService
+}
+
+func NewGroupReportHandler(svc *services.GroupReportService) *GroupReportHandler {
+	return &GroupReportHandler{
+		groupReportService: svc,
+	}
+}
+
+func (h *GroupReportHandler) GetGroupAttendance(c *gin.Context) {
+	group := c.Param("group")
+	start := c.Query("start")
+	end := c.Query("end")
+	format := c.DefaultQuery("format", "json")
+
+	if group == "" || start == "" || end == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "missing required params: group, start and end"})
+		return
+	}
+
+	if format != "json" && format != "csv" && format != "xlsx" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid format: expected json, csv or xlsx"})
+		return
+	}
+
+	login, _ := httpmw.GetUserID(c)
+	role, _ := httpmw.GetUserRole(c)
+
+	allowed, err := h.groupReportService.CanAccess(group, login, role)
+	if err != nil {
+		logger.Logger.Error().
+			Err(err).
+			Str("login", login).
+			Str("group", group).
+			Msg("failed to check group access")
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	if !allowed {
+		c.JSON(http.StatusForbidden, gin.H{"error": "group is not curated by this user"})
+		return
+	}
+
+	report, err := h.groupReportService.GetGroupAttendance(group, start, end)
+	if err != nil {
+		logger.Logger.Error().
+			Err(err).
+			Str("login", login).
+			Str("group", group).
+			Str("start", start).
+			Str("end", end).
+			Msg("failed to get group attendance")
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	filename := fmt.Sprintf("attendance_%s_%s_%s", group, start, end)
+
+	switch format {
+	case "csv":
+		writeCSV(c, filename, services.GroupAttendanceRows(report))
+	case "xlsx":
+		writeXLSX(c, filename, group, services.GroupAttendanceRows(report))
+	default:
+		c.JSON(http.StatusOK, report)
+	}
+}
@@ -1,57 +1,169 @@
 package v1
 
 import (
+	"fmt"
+	"time"
+
 	"github.com/anton1ks96/college-app-core/internal/config"
 	"github.com/anton1ks96/college-app-core/internal/httpmw"
 	"github.com/anton1ks96/college-app-core/internal/repository"
 	"github.com/anton1ks96/college-app-core/internal/services"
+	"github.com/anton1ks96/college-app-core/internal/storage"
+	"github.com/anton1ks96/college-app-core/pkg/logger"
 	"github.com/gin-gonic/gin"
 )
 
 type Handler struct {
-	cfg         *config.Config
-	schedule    *ScheduleHandler
-	attendance  *AttendanceHandler
-	performance *PerformanceHandler
-	auth        gin.HandlerFunc
+	cfg           *config.Config
+	calendar      *CalendarHandler
+	bells         *BellHandler
+	schedule      *ScheduleHandler
+	attendance    *AttendanceHandler
+	reconcile     *ReconciliationHandler
+	groupReport   *GroupReportHandler
+	performance   *PerformanceHandler
+	notifications *NotificationHandler
+	studentReport *StudentReportHandler
+	history       *HistoryHandler
+	preferences   *PreferencesHandler
+	customization *CustomizationHandler
+	personal      *PersonalHandler
+	homework      *HomeworkHandler
+	classDetails  *ClassDetailsHandler
+	auth          gin.HandlerFunc
+	optionalAuth  gin.HandlerFunc
+	staff         gin.HandlerFunc
 }
 
-func NewHandler(cfg *config.Config) *Handler {
-	portalRepo := repository.NewPortalRepository(
-		cfg.Portal.URL,
-		cfg.Portal.AttendanceURL,
-		cfg.Portal.PerformanceSubjectsURL,
-		cfg.Portal.PerformanceScoreURL,
-	)
-	scheduleService := services.NewScheduleService(portalRepo)
-	scheduleHandler := NewScheduleHandler(scheduleService)
+func NewHandler(cfg *config.Config, store storage.Storage, history *services.HistoryRecorder, portalRepo *repository.PortalRepository, calendarService *services.CalendarService, performanceService *services.PerformanceService, authMiddleware *httpmw.AuthMiddleware) *Handler {
+	calendarHandler := NewCalendarHandler(calendarService)
+
+	bellService := services.NewBellService(cfg.Bells)
+	bellHandler := NewBellHandler(bellService)
+
+	preferencesService := services.NewPreferencesService(store)
+	preferencesHandler := NewPreferencesHandler(preferencesService)
+
+	personalService := services.NewPersonalService(store, bellService)
+	personalHandler := NewPersonalHandler(personalService)
+
+	scheduleService := services.NewScheduleService(portalRepo, bellService, store, history, cfg.Schedule.Workers)
+
+	customizationService := services.NewCustomizationService(store, scheduleService)
+	customizationHandler := NewCustomizationHandler(customizationService)
+
+	location, err := time.LoadLocation(cfg.Calendar.Timezone)
+	if err != nil {
+		logger.Error(fmt.Errorf("failed to load calendar timezone %q: %w", cfg.Calendar.Timezone, err))
+		location = time.Local
+	}
+	scheduleHandler := NewScheduleHandler(scheduleService, calendarService, preferencesService, customizationService, personalService, location, cfg.Schedule.MaxGroups)
+
+	classDetailsService := services.NewClassDetailsService(portalRepo, cfg.ClassDetails)
+	classDetailsHandler := NewClassDetailsHandler(classDetailsService, cfg.ClassDetails.MaxBatch)
+
+	homeworkService := services.NewHomeworkService(scheduleService, classDetailsService, cfg.Portal.URL, cfg.Homework)
+	homeworkHandler := NewHomeworkHandler(homeworkService, preferencesService, cfg.Homework.MaxDays)
+
+	attendanceService := services.NewAttendanceService(portalRepo, scheduleService, calendarService, bellService, history, cfg.Attendance)
+	attendanceHandler := NewAttendanceHandler(attendanceService, customizationService, preferencesService)
+
+	reconciliationService := services.NewReconciliationService(scheduleService, attendanceService)
+	reconciliationHandler := NewReconciliationHandler(reconciliationService)
 
-	attendanceService := services.NewAttendanceService(portalRepo)
-	attendanceHandler := NewAttendanceHandler(attendanceService)
+	rosterRepo := repository.NewFileRosterRepository(cfg.Roster.File)
+	groupReportService := services.NewGroupReportService(rosterRepo, attendanceService, cfg.Roster)
+	groupReportHandler := NewGroupReportHandler(groupReportService)
 
-	performanceService := services.NewPerformanceService(portalRepo)
 	performanceHandler := NewPerformanceHandler(performanceService)
 
-	authMiddleware := httpmw.NewAuthMiddleware(cfg.Auth.ServiceURL, cfg.Auth.Timeout)
+	studentReportService := services.NewStudentReportService(performanceService, attendanceService)
+	studentReportHandler := NewStudentReportHandler(studentReportService)
+
+	historyService := services.NewHistoryService(store)
+	historyHandler := NewHistoryHandler(historyService)
+
+	notificationHandler := NewNotificationHandler(store)
 
 	return &Handler{
-		cfg:         cfg,
-		schedule:    scheduleHandler,
-		attendance:  attendanceHandler,
-		performance: performanceHandler,
-		auth:        authMiddleware.ValidateToken(),
+		cfg:           cfg,
+		calendar:      calendarHandler,
+		bells:         bellHandler,
+		schedule:      scheduleHandler,
+		attendance:    attendanceHandler,
+		reconcile:     reconciliationHandler,
+		groupReport:   groupReportHandler,
+		performance:   performanceHandler,
+		notifications: notificationHandler,
+		studentReport: studentReportHandler,
+		history:       historyHandler,
+		preferences:   preferencesHandler,
+		customization: customizationHandler,
+		personal:      personalHandler,
+		homework:      homeworkHandler,
+		classDetails:  classDetailsHandler,
+		auth:          authMiddleware.ValidateToken(),
+		optionalAuth:  authMiddleware.OptionalToken(),
+		staff:         httpmw.RequireRole(cfg.Roster.StaffRoles...),
 	}
 }
 
 func (h *Handler) Init(api *gin.RouterGroup) {
-	api.GET("/schedule", h.schedule.GetSchedule)
-	api.GET("/classdetails", h.schedule.GetClassDetails)
+	api.GET("/calendar", h.calendar.GetCalendar)
+	api.GET("/bells", h.bells.GetBells)
+	api.GET("/schedule", h.optionalAuth, h.schedule.GetSchedule)
+	api.GET("/schedule/conflicts", h.optionalAuth, h.schedule.GetScheduleConflicts)
+	api.GET("/classdetails", h.classDetails.GetClassDetails)
+	api.POST("/classdetails/batch", h.classDetails.GetClassDetailsBatch)
+	api.GET("/homework", h.auth, h.homework.GetHomework)
 	api.GET("/attendance", h.auth, h.attendance.GetAttendance)
 	api.GET("/attendance/streak", h.auth, h.attendance.GetAttendanceStreak)
+	api.GET("/attendance/stats", h.auth, h.attendance.GetAttendanceStats)
+	api.GET("/attendance/budget", h.auth, h.attendance.GetAbsenceBudget)
+	api.GET("/attendance/heatmap", h.auth, h.attendance.GetAttendanceHeatmap)
+	api.GET("/attendance/reconcile", h.auth, h.reconcile.Reconcile)
+	api.GET("/attendance/history", h.auth, h.history.GetAttendanceHistory)
+
+	me := api.Group("/me", h.auth)
+	{
+		me.GET("/preferences", h.preferences.GetPreferences)
+		me.PUT("/preferences", h.preferences.UpdatePreferences)
+		me.GET("/customization", h.customization.GetCustomization)
+		me.PUT("/customization", h.customization.UpdateCustomization)
+		me.GET("/schedule", h.schedule.GetMySchedule)
+		me.GET("/schedule.ics", h.schedule.ExportMySchedule)
+		me.GET("/notes", h.personal.GetNotes)
+		me.POST("/notes", h.personal.CreateNote)
+		me.PUT("/notes/:id", h.personal.UpdateNote)
+		me.DELETE("/notes/:id", h.personal.DeleteNote)
+		me.GET("/events", h.personal.GetEvents)
+		me.POST("/events", h.personal.CreateEvent)
+		me.PUT("/events/:id", h.personal.UpdateEvent)
+		me.DELETE("/events/:id", h.personal.DeleteEvent)
+	}
+
+	notifications := api.Group("/notifications", h.auth)
+	{
+		notifications.GET("/subscription", h.notifications.GetSubscription)
+		notifications.PUT("/subscription", h.notifications.Subscribe)
+		notifications.DELETE("/subscription", h.notifications.Unsubscribe)
+	}
+
+	api.GET("/reports/student", h.auth, h.studentReport.GetStudentReport)
+
+	staff := api.Group("/staff", h.auth, h.staff)
+	{
+		staff.GET("/groups/:group/attendance", h.groupReport.GetGroupAttendance)
+	}
 
 	performance := api.Group("/performance")
 	{
 		performance.GET("/subjects", h.auth, h.performance.GetSubjects)
 		performance.POST("/score", h.auth, h.performance.GetScore)
+		performance.GET("/summary", h.auth, h.performance.GetSummary)
+		performance.GET("/gradebook", h.auth, h.performance.GetGradebook)
+		performance.GET("/history", h.auth, h.history.GetScoreHistory)
+		performance.GET("/:suid/trend", h.auth, h.performance.GetTrend)
+		performance.POST("/:suid/whatif", h.auth, h.performance.WhatIf)
 	}
 }
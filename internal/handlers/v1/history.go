@@ -0,0 +1,72 @@
+package v1
+
+import (
+	"net/http"
+
+	"github.com/anton1ks96/college-app-core/internal/httpmw"
+	"github.com/anton1ks96/college-app-core/internal/services"
+	"github.com/anton1ks96/college-app-core/pkg/logger"
+	"github.com/gin-gonic/gin"
+)
+
+type HistoryHandler struct {
+	historyService *services.HistoryService
+}
+
+func NewHistoryHandler(svc *services.HistoryService) *HistoryHandler {
+	return &HistoryHandler{
+		historyService: svc,
+	}
+}
+
+func (h *HistoryHandler) GetAttendanceHistory(c *gin.Context) {
+	start := c.Query("start")
+	end := c.Query("end")
+
+	if start == "" || end == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "missing required query params: start and end"})
+		return
+	}
+
+	login, _ := httpmw.GetUserID(c)
+
+	history, err := h.historyService.GetAttendanceHistory(login, start, end)
+	if err != nil {
+		logger.Logger.Error().
+			Err(err).
+			Str("login", login).
+			Str("start", start).
+			Str("end", end).
+			Msg("failed to get attendance history")
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, history)
+}
+
+func (h *HistoryHandler) GetScoreHistory(c *gin.Context) {
+	start := c.Query("start")
+	end := c.Query("end")
+
+	if start == "" || end == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "missing required query params: start and end"})
+		return
+	}
+
+	login, _ := httpmw.GetUserID(c)
+
+	history, err := h.historyService.GetScoreHistory(login, start, end)
+	if err != nil {
+		logger.Logger.Error().
+			Err(err).
+			Str("login", login).
+			Str("start", start).
+			Str("end", end).
+			Msg("failed to get score history")
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, history)
+}
@@ -0,0 +1,69 @@
+package v1
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/anton1ks96/college-app-core/internal/services"
+	"github.com/anton1ks96/college-app-core/pkg/logger"
+	"github.com/gin-gonic/gin"
+)
+
+type HomeworkHandler struct {
+	homeworkService    *services.HomeworkService
+	preferencesService *services.PreferencesService
+	maxDays            int
+}
+
+func NewHomeworkHandler(svc *services.HomeworkService, preferences *services.PreferencesService, maxDays int) *HomeworkHandler {
+	return &HomeworkHandler{
+		homeworkService:    svc,
+		preferencesService: preferences,
+		maxDays:            maxDays,
+	}
+}
+
+func (h *HomeworkHandler) GetHomework(c *gin.Context) {
+	start := c.Query("start")
+	end := c.Query("end")
+	sel, _ := resolveSelection(c, h.preferencesService)
+
+	if sel.Group == "" || start == "" || end == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "missing required query params: group, start and end"})
+		return
+	}
+
+	from, err := time.Parse("2006-01-02", start)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid start date: expected YYYY-MM-DD"})
+		return
+	}
+	to, err := time.Parse("2006-01-02", end)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid end date: expected YYYY-MM-DD"})
+		return
+	}
+	if to.Before(from) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "end date must not be before start date"})
+		return
+	}
+	if h.maxDays > 0 && to.Sub(from) >= time.Duration(h.maxDays)*24*time.Hour {
+		c.JSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("date range must not exceed %d days", h.maxDays)})
+		return
+	}
+
+	homework, err := h.homeworkService.GetHomework(sel, start, end)
+	if err != nil {
+		logger.Logger.Error().
+			Err(err).
+			Str("group", sel.Group).
+			Str("start", start).
+			Str("end", end).
+			Msg("failed to get homework")
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, homework)
+}
@@ -0,0 +1,66 @@
+package v1
+
+import (
+	"net/http"
+
+	"github.com/anton1ks96/college-app-core/internal/httpmw"
+	"github.com/anton1ks96/college-app-core/internal/repository"
+	"github.com/anton1ks96/college-app-core/pkg/logger"
+	"github.com/gin-gonic/gin"
+)
+
+type NotificationHandler struct {
+	notifications repository.NotificationRepository
+}
+
+func NewNotificationHandler(repo repository.NotificationRepository) *NotificationHandler {
+	return &NotificationHandler{
+		notifications: repo,
+	}
+}
+
+func (h *NotificationHandler) GetSubscription(c *gin.Context) {
+	login, _ := httpmw.GetUserID(c)
+
+	subscribed, err := h.notifications.IsSubscribed(login)
+	if err != nil {
+		logger.Logger.Error().
+			Err(err).
+			Str("login", login).
+			Msg("failed to get grade subscription")
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"subscribed": subscribed})
+}
+
+func (h *NotificationHandler) Subscribe(c *gin.Context) {
+	login, _ := httpmw.GetUserID(c)
+
+	if err := h.notifications.Subscribe(login); err != nil {
+		logger.Logger.Error().
+			Err(err).
+			Str("login", login).
+			Msg("failed to subscribe to grade notifications")
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"subscribed": true})
+}
+
+func (h *NotificationHandler) Unsubscribe(c *gin.Context) {
+	login, _ := httpmw.GetUserID(c)
+
+	if err := h.notifications.Unsubscribe(login); err != nil {
+		logger.Logger.Error().
+			Err(err).
+			Str("login", login).
+			Msg("failed to unsubscribe from grade notifications")
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"subscribed": false})
+}
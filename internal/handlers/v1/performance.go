@@ -1,8 +1,11 @@
 package v1
 
 import (
+	"fmt"
 	"net/http"
+	"strconv"
 
+	"github.com/anton1ks96/college-app-core/internal/domain"
 	"github.com/anton1ks96/college-app-core/internal/httpmw"
 	"github.com/anton1ks96/college-app-core/internal/services"
 	"github.com/anton1ks96/college-app-core/pkg/logger"
@@ -35,14 +38,8 @@ func (h *PerformanceHandler) GetSubjects(c *gin.Context) {
 	c.JSON(http.StatusOK, subjects)
 }
 
-type scoreRequest struct {
-	SuID      string `json:"SuID"`
-	Datastart string `json:"datastart"`
-	Dataend   string `json:"dataend"`
-}
-
 func (h *PerformanceHandler) GetScore(c *gin.Context) {
-	var req scoreRequest
+	var req domain.PerformanceScoreRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
 		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid request body"})
 		return
@@ -70,3 +67,152 @@ func (h *PerformanceHandler) GetScore(c *gin.Context) {
 
 	c.JSON(http.StatusOK, scores)
 }
+
+func (h *PerformanceHandler) GetSummary(c *gin.Context) {
+	start := c.Query("start")
+	end := c.Query("end")
+
+	if start == "" || end == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "missing required query params: start and end"})
+		return
+	}
+
+	login, _ := httpmw.GetUserID(c)
+
+	summary, err := h.performanceService.GetSummary(login, start, end)
+	if err != nil {
+		logger.Logger.Error().
+			Err(err).
+			Str("login", login).
+			Str("start", start).
+			Str("end", end).
+			Msg("failed to get performance summary")
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, summary)
+}
+
+func (h *PerformanceHandler) GetGradebook(c *gin.Context) {
+	start := c.Query("start")
+	end := c.Query("end")
+
+	if start == "" || end == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "missing required query params: start and end"})
+		return
+	}
+
+	login, _ := httpmw.GetUserID(c)
+
+	gradebook, err := h.performanceService.GetGradebook(login, start, end)
+	if err != nil {
+		logger.Logger.Error().
+			Err(err).
+			Str("login", login).
+			Str("start", start).
+			Str("end", end).
+			Msg("failed to get gradebook")
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gradebook)
+}
+
+func (h *PerformanceHandler) GetTrend(c *gin.Context) {
+	suID := c.Param("suid")
+	start := c.Query("start")
+	end := c.Query("end")
+
+	if suID == "" || start == "" || end == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "missing required params: suid, start and end"})
+		return
+	}
+
+	window, err := parseIntQuery(c, "window")
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	last, err := parseIntQuery(c, "last")
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	login, _ := httpmw.GetUserID(c)
+
+	trend, err := h.performanceService.GetTrend(login, suID, start, end, window, last)
+	if err != nil {
+		logger.Logger.Error().
+			Err(err).
+			Str("login", login).
+			Str("suID", suID).
+			Str("start", start).
+			Str("end", end).
+			Msg("failed to get grade trend")
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, trend)
+}
+
+type whatIfRequest struct {
+	Datastart string               `json:"datastart"`
+	Dataend   string               `json:"dataend"`
+	Scores    []domain.WhatIfScore `json:"scores"`
+}
+
+func (h *PerformanceHandler) WhatIf(c *gin.Context) {
+	suID := c.Param("suid")
+
+	var req whatIfRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid request body"})
+		return
+	}
+
+	if suID == "" || req.Datastart == "" || req.Dataend == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "missing required fields: suid, datastart, dataend"})
+		return
+	}
+
+	for i, score := range req.Scores {
+		if score.MaxScore <= 0 || score.Value < 0 || score.Value > float64(score.MaxScore) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("invalid score %d: value must be between 0 and a positive MaxScore", i+1)})
+			return
+		}
+	}
+
+	login, _ := httpmw.GetUserID(c)
+
+	result, err := h.performanceService.WhatIf(login, suID, req.Datastart, req.Dataend, req.Scores)
+	if err != nil {
+		logger.Logger.Error().
+			Err(err).
+			Str("login", login).
+			Str("suID", suID).
+			Str("datastart", req.Datastart).
+			Str("dataend", req.Dataend).
+			Msg("failed to calculate what-if grade")
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, result)
+}
+
+func parseIntQuery(c *gin.Context, key string) (int, error) {
+	value := c.Query(key)
+	if value == "" {
+		return 0, nil
+	}
+
+	parsed, err := strconv.Atoi(value)
+	if err != nil || parsed < 0 {
+		return 0, fmt.Errorf("invalid %s: expected a non-negative integer", key)
+	}
+	return parsed, nil
+}
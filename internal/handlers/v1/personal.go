@@ -0,0 +1,248 @@
+package v1
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/anton1ks96/college-app-core/internal/domain"
+	"github.com/anton1ks96/college-app-core/internal/httpmw"
+	"github.com/anton1ks96/college-app-core/internal/services"
+	"github.com/anton1ks96/college-app-core/pkg/logger"
+	"github.com/gin-gonic/gin"
+)
+
+type PersonalHandler struct {
+	personalService *services.PersonalService
+}
+
+func NewPersonalHandler(svc *services.PersonalService) *PersonalHandler {
+	return &PersonalHandler{
+		personalService: svc,
+	}
+}
+
+type noteRequest struct {
+	ClID string `json:"ClID"`
+	Text string `json:"text"`
+}
+
+func (h *PersonalHandler) GetNotes(c *gin.Context) {
+	login, _ := httpmw.GetUserID(c)
+
+	notes, err := h.personalService.GetNotes(login, c.Query("clid"))
+	if err != nil {
+		logger.Logger.Error().
+			Err(err).
+			Str("login", login).
+			Msg("failed to get notes")
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, notes)
+}
+
+func (h *PersonalHandler) CreateNote(c *gin.Context) {
+	var req noteRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid request body"})
+		return
+	}
+
+	if req.ClID == "" || strings.TrimSpace(req.Text) == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "missing required fields: ClID and text"})
+		return
+	}
+
+	login, _ := httpmw.GetUserID(c)
+
+	note, err := h.personalService.CreateNote(login, req.ClID, req.Text)
+	if err != nil {
+		logger.Logger.Error().
+			Err(err).
+			Str("login", login).
+			Msg("failed to create note")
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusCreated, note)
+}
+
+func (h *PersonalHandler) UpdateNote(c *gin.Context) {
+	id := c.Param("id")
+
+	var req noteRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid request body"})
+		return
+	}
+
+	if strings.TrimSpace(req.Text) == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "missing required field: text"})
+		return
+	}
+
+	login, _ := httpmw.GetUserID(c)
+
+	note, found, err := h.personalService.UpdateNote(login, id, req.Text)
+	if err != nil {
+		logger.Logger.Error().
+			Err(err).
+			Str("login", login).
+			Str("id", id).
+			Msg("failed to update note")
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	if !found {
+		c.JSON(http.StatusNotFound, gin.H{"error": "note not found"})
+		return
+	}
+
+	c.JSON(http.StatusOK, note)
+}
+
+func (h *PersonalHandler) DeleteNote(c *gin.Context) {
+	id := c.Param("id")
+	login, _ := httpmw.GetUserID(c)
+
+	found, err := h.personalService.DeleteNote(login, id)
+	if err != nil {
+		logger.Logger.Error().
+			Err(err).
+			Str("login", login).
+			Str("id", id).
+			Msg("failed to delete note")
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	if !found {
+		c.JSON(http.StatusNotFound, gin.H{"error": "note not found"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"deleted": true})
+}
+
+func (h *PersonalHandler) GetEvents(c *gin.Context) {
+	login, _ := httpmw.GetUserID(c)
+
+	events, err := h.personalService.GetEvents(login, c.Query("start"), c.Query("end"))
+	if err != nil {
+		logger.Logger.Error().
+			Err(err).
+			Str("login", login).
+			Msg("failed to get personal events")
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, events)
+}
+
+func (h *PersonalHandler) CreateEvent(c *gin.Context) {
+	event, ok := bindPersonalEvent(c)
+	if !ok {
+		return
+	}
+
+	login, _ := httpmw.GetUserID(c)
+
+	created, err := h.personalService.CreateEvent(login, event)
+	if err != nil {
+		logger.Logger.Error().
+			Err(err).
+			Str("login", login).
+			Msg("failed to create personal event")
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusCreated, created)
+}
+
+func (h *PersonalHandler) UpdateEvent(c *gin.Context) {
+	id := c.Param("id")
+
+	event, ok := bindPersonalEvent(c)
+	if !ok {
+		return
+	}
+
+	login, _ := httpmw.GetUserID(c)
+
+	updated, found, err := h.personalService.UpdateEvent(login, id, event)
+	if err != nil {
+		logger.Logger.Error().
+			Err(err).
+			Str("login", login).
+			Str("id", id).
+			Msg("failed to update personal event")
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	if !found {
+		c.JSON(http.StatusNotFound, gin.H{"error": "event not found"})
+		return
+	}
+
+	c.JSON(http.StatusOK, updated)
+}
+
+func (h *PersonalHandler) DeleteEvent(c *gin.Context) {
+	id := c.Param("id")
+	login, _ := httpmw.GetUserID(c)
+
+	found, err := h.personalService.DeleteEvent(login, id)
+	if err != nil {
+		logger.Logger.Error().
+			Err(err).
+			Str("login", login).
+			Str("id", id).
+			Msg("failed to delete personal event")
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	if !found {
+		c.JSON(http.StatusNotFound, gin.H{"error": "event not found"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"deleted": true})
+}
+
+func bindPersonalEvent(c *gin.Context) (domain.PersonalEvent, bool) {
+	var event domain.PersonalEvent
+	if err := c.ShouldBindJSON(&event); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid request body"})
+		return event, false
+	}
+
+	event.Title = strings.TrimSpace(event.Title)
+	if event.Title == "" || event.Day == "" || event.Start == "" || event.End == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "missing required fields: title, Day, start and end"})
+		return event, false
+	}
+
+	if _, err := time.Parse("2006-01-02", event.Day); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("invalid Day %q: expected YYYY-MM-DD", event.Day)})
+		return event, false
+	}
+
+	start, errStart := time.Parse("15:04", event.Start)
+	end, errEnd := time.Parse("15:04", event.End)
+	if errStart != nil || errEnd != nil || !end.After(start) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid start or end: expected HH:MM with end after start"})
+		return event, false
+	}
+
+	if event.Color != "" && !colorRe.MatchString(event.Color) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid color: expected a hex value like #1e88e5"})
+		return event, false
+	}
+
+	return event, true
+}
@@ -0,0 +1,73 @@
+package v1
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/anton1ks96/college-app-core/internal/domain"
+	"github.com/anton1ks96/college-app-core/internal/httpmw"
+	"github.com/anton1ks96/college-app-core/internal/services"
+	"github.com/anton1ks96/college-app-core/pkg/logger"
+	"github.com/gin-gonic/gin"
+)
+
+type PreferencesHandler struct {
+	preferencesService *services.PreferencesService
+}
+
+func NewPreferencesHandler(svc *services.PreferencesService) *PreferencesHandler {
+	return &PreferencesHandler{
+		preferencesService: svc,
+	}
+}
+
+func (h *PreferencesHandler) GetPreferences(c *gin.Context) {
+	login, _ := httpmw.GetUserID(c)
+
+	prefs, err := h.preferencesService.GetPreferences(login)
+	if err != nil {
+		logger.Logger.Error().
+			Err(err).
+			Str("login", login).
+			Msg("failed to get preferences")
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, prefs)
+}
+
+func (h *PreferencesHandler) UpdatePreferences(c *gin.Context) {
+	var req domain.UserPreferences
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid request body"})
+		return
+	}
+
+	if req.Timezone != "" {
+		if _, err := time.LoadLocation(req.Timezone); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("invalid timezone %q", req.Timezone)})
+			return
+		}
+	}
+
+	if req.Group == "" && (req.Subgroup != "" || req.EnglishGroup != "" || req.ProfileSubgroup != "") {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "group is required when subgroups are set"})
+		return
+	}
+
+	login, _ := httpmw.GetUserID(c)
+
+	prefs, err := h.preferencesService.UpdatePreferences(login, req)
+	if err != nil {
+		logger.Logger.Error().
+			Err(err).
+			Str("login", login).
+			Msg("failed to update preferences")
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, prefs)
+}
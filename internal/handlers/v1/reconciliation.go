@@ -0,0 +1,54 @@
+package v1
+
+import (
+	"net/http"
+
+	"github.com/anton1ks96/college-app-core/internal/domain"
+	"github.com/anton1ks96/college-app-core/internal/httpmw"
+	"github.com/anton1ks96/college-app-core/internal/services"
+	"github.com/anton1ks96/college-app-core/pkg/logger"
+	"github.com/gin-gonic/gin"
+)
+
+type ReconciliationHandler struct {
+	reconciliationService *services.ReconciliationService
+}
+
+func NewReconciliationHandler(svc *services.ReconciliationService) *ReconciliationHandler {
+	return &ReconciliationHandler{
+		reconciliationService: svc,
+	}
+}
+
+func (h *ReconciliationHandler) Reconcile(c *gin.Context) {
+	sel := domain.ScheduleSelection{
+		Group:           c.Query("group"),
+		Subgroup:        c.Query("subgroup"),
+		EnglishGroup:    c.Query("english_group"),
+		ProfileSubgroup: c.Query("profile_subgroup"),
+	}
+	start := c.Query("start")
+	end := c.Query("end")
+
+	if sel.Group == "" || start == "" || end == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "missing required query params: group, start and end"})
+		return
+	}
+
+	login, _ := httpmw.GetUserID(c)
+
+	report, err := h.reconciliationService.Reconcile(login, sel, start, end)
+	if err != nil {
+		logger.Logger.Error().
+			Err(err).
+			Str("login", login).
+			Str("group", sel.Group).
+			Str("start", start).
+			Str("end", end).
+			Msg("failed to reconcile attendance")
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, report)
+}
@@ -1,58 +1,325 @@
 package v1
 
 import (
+	"fmt"
 	"net/http"
+	"time"
 
 	"github.com/anton1ks96/college-app-core/internal/domain"
+	"github.com/anton1ks96/college-app-core/internal/httpmw"
 	"github.com/anton1ks96/college-app-core/internal/services"
+	"github.com/anton1ks96/college-app-core/pkg/logger"
 	"github.com/gin-gonic/gin"
 )
 
 type ScheduleHandler struct {
-	scheduleService *services.ScheduleService
+	scheduleService      *services.ScheduleService
+	calendarService      *services.CalendarService
+	preferencesService   *services.PreferencesService
+	customizationService *services.CustomizationService
+	personalService      *services.PersonalService
+	location             *time.Location
+	maxGroups            int
 }
 
-func NewScheduleHandler(svc *services.ScheduleService) *ScheduleHandler {
+func NewScheduleHandler(svc *services.ScheduleService, calendar *services.CalendarService, preferences *services.PreferencesService, customization *services.CustomizationService, personal *services.PersonalService, location *time.Location, maxGroups int) *ScheduleHandler {
 	return &ScheduleHandler{
-		scheduleService: svc,
+		scheduleService:      svc,
+		calendarService:      calendar,
+		preferencesService:   preferences,
+		customizationService: customization,
+		personalService:      personal,
+		location:             location,
+		maxGroups:            maxGroups,
 	}
 }
 
 func (h *ScheduleHandler) GetSchedule(c *gin.Context) {
-	group := c.Query("group")
-	subgroup := c.Query("subgroup")
-	englishGroup := c.Query("english_group")
-	profileSubgroup := c.Query("profile_subgroup")
-	start := c.Query("start")
-	end := c.Query("end")
+	if len(c.QueryArray("group")) > 1 {
+		h.getMergedSchedule(c)
+		return
+	}
+
+	sel, prefs := resolveSelection(c, h.preferencesService)
+	start, end, err := h.resolveRange(c, h.now(prefs))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
 
-	if group == "" || start == "" || end == "" {
+	if sel.Group == "" || start == "" || end == "" {
 		c.JSON(http.StatusBadRequest, gin.H{"error": "missing required query params"})
 		return
 	}
 
-	events, err := h.scheduleService.GetSchedule(group, subgroup, englishGroup, profileSubgroup, start, end)
+	events, err := h.scheduleService.GetSchedule(sel.Group, sel.Subgroup, sel.EnglishGroup, sel.ProfileSubgroup, start, end)
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
 	}
 
-	resp := domain.ScheduleResponse{Events: events}
+	if prefs != nil {
+		events = services.FilterHiddenSubjects(events, prefs.HiddenSubjects)
+	}
+	events = h.customize(c, events)
+
+	resp := domain.ScheduleResponse{Events: events, Days: h.calendarDays(start, end)}
+	c.JSON(http.StatusOK, resp)
+}
+
+func (h *ScheduleHandler) GetMySchedule(c *gin.Context) {
+	events, start, end, ok := h.mySchedule(c)
+	if !ok {
+		return
+	}
+
+	resp := domain.ScheduleResponse{Events: events, Days: h.calendarDays(start, end)}
 	c.JSON(http.StatusOK, resp)
 }
 
-func (h *ScheduleHandler) GetClassDetails(c *gin.Context) {
-	clid := c.Query("id")
-	if clid == "" {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "missing id"})
+func (h *ScheduleHandler) ExportMySchedule(c *gin.Context) {
+	events, start, end, ok := h.mySchedule(c)
+	if !ok {
 		return
 	}
 
-	details, err := h.scheduleService.GetClassDetails(clid)
+	login, _ := httpmw.GetUserID(c)
+	filename := fmt.Sprintf("schedule_%s_%s_%s", login, start, end)
+
+	writeICal(c, filename, "Расписание", services.ScheduleCalendarEvents(events, h.location))
+}
+
+func (h *ScheduleHandler) mySchedule(c *gin.Context) ([]domain.ScheduleEvent, string, string, bool) {
+	sel, prefs := resolveSelection(c, h.preferencesService)
+	start, end, err := h.resolveRange(c, h.now(prefs))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return nil, "", "", false
+	}
+
+	if start == "" || end == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "missing required query params: start and end, week or period"})
+		return nil, "", "", false
+	}
+
+	login, _ := httpmw.GetUserID(c)
+
+	events := make([]domain.ScheduleEvent, 0)
+	if sel.Group != "" {
+		events, err = h.scheduleService.GetSchedule(sel.Group, sel.Subgroup, sel.EnglishGroup, sel.ProfileSubgroup, start, end)
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return nil, "", "", false
+		}
+		if prefs != nil {
+			events = services.FilterHiddenSubjects(events, prefs.HiddenSubjects)
+		}
+		events = h.customize(c, events)
+	}
+
+	merged, err := h.personalService.MergeSchedule(login, events, start, end)
 	if err != nil {
+		logger.Logger.Error().
+			Err(err).
+			Str("login", login).
+			Str("start", start).
+			Str("end", end).
+			Msg("failed to merge personal schedule")
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return nil, "", "", false
+	}
+
+	return merged, start, end, true
+}
+
+func (h *ScheduleHandler) getMergedSchedule(c *gin.Context) {
+	groups := c.QueryArray("group")
+	subgroups := c.QueryArray("subgroup")
+	englishGroups := c.QueryArray("english_group")
+	profileSubgroups := c.QueryArray("profile_subgroup")
+
+	params := []struct {
+		name   string
+		values []string
+	}{
+		{"subgroup", subgroups},
+		{"english_group", englishGroups},
+		{"profile_subgroup", profileSubgroups},
+	}
+	for _, p := range params {
+		if len(p.values) > 0 && len(p.values) != len(groups) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("%s must be given once per group: got %d values for %d groups", p.name, len(p.values), len(groups))})
+			return
+		}
+	}
+
+	prefs := h.preferences(c)
+	start, end, err := h.resolveRange(c, h.now(prefs))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 		return
 	}
 
-	c.JSON(http.StatusOK, details)
+	if start == "" || end == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "missing required query params"})
+		return
+	}
+
+	if h.maxGroups > 0 && len(groups) > h.maxGroups {
+		c.JSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("too many groups: at most %d allowed", h.maxGroups)})
+		return
+	}
+
+	selections := make([]domain.ScheduleSelection, len(groups))
+	for i, group := range groups {
+		if group == "" {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "group must not be empty"})
+			return
+		}
+		selections[i], _ = services.ApplyPreferences(domain.ScheduleSelection{
+			Group:           group,
+			Subgroup:        queryAt(subgroups, i),
+			EnglishGroup:    queryAt(englishGroups, i),
+			ProfileSubgroup: queryAt(profileSubgroups, i),
+		}, prefs)
+	}
+
+	events, err := h.scheduleService.GetMergedSchedule(selections, start, end)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	if prefs != nil {
+		events = services.FilterHiddenSubjectsForGroup(events, prefs.Group, prefs.HiddenSubjects)
+	}
+	events = h.customize(c, events)
+
+	resp := domain.ScheduleResponse{Events: events, Days: h.calendarDays(start, end)}
+	c.JSON(http.StatusOK, resp)
+}
+
+func (h *ScheduleHandler) preferences(c *gin.Context) *domain.UserPreferences {
+	login, ok := httpmw.GetUserID(c)
+	if !ok {
+		return nil
+	}
+
+	prefs, err := h.preferencesService.GetPreferences(login)
+	if err != nil {
+		logger.Logger.Error().
+			Err(err).
+			Str("login", login).
+			Msg("failed to load schedule preferences")
+		return nil
+	}
+
+	return prefs
+}
+
+func (h *ScheduleHandler) resolveRange(c *gin.Context, now time.Time) (string, string, error) {
+	start := c.Query("start")
+	end := c.Query("end")
+	if start != "" && end != "" {
+		return start, end, nil
+	}
+
+	if week := c.Query("week"); week != "" {
+		return h.calendarService.ResolveWeek(week, now)
+	}
+	if period := c.Query("period"); period != "" {
+		return h.calendarService.ResolvePeriod(period, now)
+	}
+
+	return start, end, nil
+}
+
+func resolveSelection(c *gin.Context, preferences *services.PreferencesService) (domain.ScheduleSelection, *domain.UserPreferences) {
+	sel := domain.ScheduleSelection{
+		Group:           c.Query("group"),
+		Subgroup:        c.Query("subgroup"),
+		EnglishGroup:    c.Query("english_group"),
+		ProfileSubgroup: c.Query("profile_subgroup"),
+	}
+
+	login, ok := httpmw.GetUserID(c)
+	if !ok {
+		return sel, nil
+	}
+
+	resolved, prefs, err := preferences.ResolveSelection(login, sel)
+	if err != nil {
+		logger.Logger.Error().
+			Err(err).
+			Str("login", login).
+			Msg("failed to apply schedule preferences")
+		return sel, nil
+	}
+
+	return resolved, prefs
+}
+
+func (h *ScheduleHandler) customize(c *gin.Context, events []domain.ScheduleEvent) []domain.ScheduleEvent {
+	login, ok := httpmw.GetUserID(c)
+	if !ok {
+		return events
+	}
+
+	customized, err := h.customizationService.CustomizeSchedule(login, events)
+	if err != nil {
+		logger.Logger.Error().
+			Err(err).
+			Str("login", login).
+			Msg("failed to apply schedule customization")
+		return events
+	}
+
+	return customized
+}
+
+func (h *ScheduleHandler) now(prefs *domain.UserPreferences) time.Time {
+	return time.Now().In(h.preferencesService.Location(prefs))
+}
+
+func (h *ScheduleHandler) calendarDays(start, end string) []domain.CalendarDay {
+	days, err := h.calendarService.Days(start, end)
+	if err != nil {
+		logger.Logger.Warn().
+			Err(err).
+			Str("start", start).
+			Str("end", end).
+			Msg("failed to resolve calendar days for schedule")
+		return nil
+	}
+	return days
+}
+
+func queryAt(values []string, i int) string {
+	if i < len(values) {
+		return values[i]
+	}
+	return ""
+}
+
+func (h *ScheduleHandler) GetScheduleConflicts(c *gin.Context) {
+	sel, prefs := resolveSelection(c, h.preferencesService)
+	start, end, err := h.resolveRange(c, h.now(prefs))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if sel.Group == "" || sel.Subgroup == "" || start == "" || end == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "missing required query params: group, subgroup, start and end"})
+		return
+	}
+
+	conflicts, err := h.scheduleService.GetScheduleConflicts(sel.Group, sel.Subgroup, sel.EnglishGroup, sel.ProfileSubgroup, start, end)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	resp := domain.ScheduleConflictsResponse{Conflicts: conflicts}
+	c.JSON(http.StatusOK, resp)
 }
@@ -0,0 +1,70 @@
+package v1
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/anton1ks96/college-app-core/internal/httpmw"
+	"github.com/anton1ks96/college-app-core/internal/services"
+	"github.com/anton1ks96/college-app-core/pkg/logger"
+	"github.com/gin-gonic/gin"
+)
+
+type StudentReportHandler struct {
+	studentReportService *services.StudentReportService
+}
+
+func NewStudentReportHandler(svc *services.StudentReportService) *StudentReportHandler {
+	return &StudentReportHandler{
+		studentReportService: svc,
+	}
+}
+
+func (h *StudentReportHandler) GetStudentReport(c *gin.Context) {
+	start := c.Query("start")
+	end := c.Query("end")
+	format := c.DefaultQuery("format", "pdf")
+
+	if start == "" || end == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "missing required query params: start and end"})
+		return
+	}
+
+	if format != "pdf" && format != "csv" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid format: expected pdf or csv"})
+		return
+	}
+
+	login, _ := httpmw.GetUserID(c)
+
+	report, err := h.studentReportService.GetStudentReport(login, start, end)
+	if err != nil {
+		logger.Logger.Error().
+			Err(err).
+			Str("login", login).
+			Str("start", start).
+			Str("end", end).
+			Msg("failed to get student report")
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	filename := fmt.Sprintf("report_%s_%s_%s", login, start, end)
+
+	if format == "csv" {
+		writeCSV(c, filename, services.StudentReportRows(report))
+		return
+	}
+
+	doc, err := services.StudentReportPDF(report)
+	if err != nil {
+		logger.Logger.Error().
+			Err(err).
+			Str("login", login).
+			Msg("failed to render student report")
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	writePDF(c, filename, doc)
+}
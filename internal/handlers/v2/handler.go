@@ -0,0 +1,31 @@
+package v2
+
+import (
+	"github.com/anton1ks96/college-app-core/internal/config"
+	"github.com/anton1ks96/college-app-core/internal/httpmw"
+	"github.com/anton1ks96/college-app-core/internal/services"
+	"github.com/gin-gonic/gin"
+)
+
+type Handler struct {
+	cfg         *config.Config
+	performance *PerformanceHandler
+	auth        gin.HandlerFunc
+}
+
+func NewHandler(cfg *config.Config, performanceService *services.PerformanceService, authMiddleware *httpmw.AuthMiddleware) *Handler {
+	performanceHandler := NewPerformanceHandler(performanceService)
+
+	return &Handler{
+		cfg:         cfg,
+		performance: performanceHandler,
+		auth:        authMiddleware.ValidateToken(),
+	}
+}
+
+func (h *Handler) Init(api *gin.RouterGroup) {
+	performance := api.Group("/performance")
+	{
+		performance.POST("/score", h.auth, h.performance.GetScore)
+	}
+}
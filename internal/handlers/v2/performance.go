@@ -0,0 +1,51 @@
+package v2
+
+import (
+	"net/http"
+
+	"github.com/anton1ks96/college-app-core/internal/domain"
+	"github.com/anton1ks96/college-app-core/internal/httpmw"
+	"github.com/anton1ks96/college-app-core/internal/services"
+	"github.com/anton1ks96/college-app-core/pkg/logger"
+	"github.com/gin-gonic/gin"
+)
+
+type PerformanceHandler struct {
+	performanceService *services.PerformanceService
+}
+
+func NewPerformanceHandler(svc *services.PerformanceService) *PerformanceHandler {
+	return &PerformanceHandler{
+		performanceService: svc,
+	}
+}
+
+func (h *PerformanceHandler) GetScore(c *gin.Context) {
+	var req domain.PerformanceScoreRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid request body"})
+		return
+	}
+
+	if req.SuID == "" || req.Datastart == "" || req.Dataend == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "missing required fields: SuID, datastart, dataend"})
+		return
+	}
+
+	login, _ := httpmw.GetUserID(c)
+
+	book, err := h.performanceService.GetScoreBook(login, req.SuID, req.Datastart, req.Dataend)
+	if err != nil {
+		logger.Logger.Error().
+			Err(err).
+			Str("login", login).
+			Str("suID", req.SuID).
+			Str("datastart", req.Datastart).
+			Str("dataend", req.Dataend).
+			Msg("failed to get performance score")
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, book)
+}
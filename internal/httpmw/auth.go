@@ -61,7 +61,7 @@ func (m *AuthMiddleware) ValidateToken() gin.HandlerFunc {
 
 		token := parts[1]
 
-		valid, userID, err := m.validateWithAuthService(token)
+		valid, user, err := m.validateWithAuthService(token)
 		if err != nil {
 			logger.Error(err)
 			c.JSON(http.StatusUnauthorized, gin.H{
@@ -80,24 +80,68 @@ func (m *AuthMiddleware) ValidateToken() gin.HandlerFunc {
 			return
 		}
 
-		c.Set("user_id", userID)
+		c.Set("user_id", user.ID)
+		c.Set("user_role", user.Role)
 		c.Next()
 	}
 }
 
-func (m *AuthMiddleware) validateWithAuthService(token string) (bool, string, error) {
+func (m *AuthMiddleware) OptionalToken() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		parts := strings.SplitN(c.GetHeader("Authorization"), " ", 2)
+		if len(parts) != 2 || strings.ToLower(parts[0]) != "bearer" {
+			c.Next()
+			return
+		}
+
+		token := parts[1]
+
+		valid, user, err := m.validateWithAuthService(token)
+		if err != nil {
+			logger.Error(err)
+			c.Next()
+			return
+		}
+
+		if valid {
+			c.Set("user_id", user.ID)
+			c.Set("user_role", user.Role)
+		}
+		c.Next()
+	}
+}
+
+func RequireRole(roles ...string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		role, _ := GetUserRole(c)
+		for _, allowed := range roles {
+			if strings.EqualFold(role, allowed) {
+				c.Next()
+				return
+			}
+		}
+
+		logger.Warn(fmt.Sprintf("Access denied for role %q", role))
+		c.JSON(http.StatusForbidden, gin.H{
+			"error": "insufficient permissions",
+		})
+		c.Abort()
+	}
+}
+
+func (m *AuthMiddleware) validateWithAuthService(token string) (bool, *domain.User, error) {
 	reqBody := ValidationRequest{
 		Token: token,
 	}
 
 	jsonData, err := json.Marshal(reqBody)
 	if err != nil {
-		return false, "", fmt.Errorf("failed to marshal request: %w", err)
+		return false, nil, fmt.Errorf("failed to marshal request: %w", err)
 	}
 
 	req, err := http.NewRequest("POST", m.validationURL, bytes.NewBuffer(jsonData))
 	if err != nil {
-		return false, "", fmt.Errorf("failed to create request: %w", err)
+		return false, nil, fmt.Errorf("failed to create request: %w", err)
 	}
 
 	req.Header.Set("Content-Type", "application/json")
@@ -105,28 +149,28 @@ func (m *AuthMiddleware) validateWithAuthService(token string) (bool, string, er
 
 	resp, err := m.client.Do(req)
 	if err != nil {
-		return false, "", fmt.Errorf("failed to send request: %w", err)
+		return false, nil, fmt.Errorf("failed to send request: %w", err)
 	}
 	defer resp.Body.Close()
 
 	if resp.StatusCode != http.StatusOK {
-		return false, "", fmt.Errorf("auth service returned status: %d", resp.StatusCode)
+		return false, nil, fmt.Errorf("auth service returned status: %d", resp.StatusCode)
 	}
 
 	var validationResp ValidationResponse
 	if err := json.NewDecoder(resp.Body).Decode(&validationResp); err != nil {
-		return false, "", fmt.Errorf("failed to decode response: %w", err)
+		return false, nil, fmt.Errorf("failed to decode response: %w", err)
 	}
 
 	if !validationResp.Valid {
-		return false, "", nil
+		return false, nil, nil
 	}
 
 	if validationResp.User == nil {
-		return false, "", fmt.Errorf("valid response but user data is missing")
+		return false, nil, fmt.Errorf("valid response but user data is missing")
 	}
 
-	return true, validationResp.User.ID, nil
+	return true, validationResp.User, nil
 }
 
 func GetUserID(c *gin.Context) (string, bool) {
@@ -138,3 +182,13 @@ func GetUserID(c *gin.Context) (string, bool) {
 	id, ok := userID.(string)
 	return id, ok
 }
+
+func GetUserRole(c *gin.Context) (string, bool) {
+	role, exists := c.Get("user_role")
+	if !exists {
+		return "", false
+	}
+
+	r, ok := role.(string)
+	return r, ok
+}
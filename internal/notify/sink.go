@@ -0,0 +1,142 @@
+package notify
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/anton1ks96/college-app-core/internal/config"
+	"github.com/anton1ks96/college-app-core/internal/domain"
+	"github.com/anton1ks96/college-app-core/pkg/logger"
+)
+
+type Sink interface {
+	Send(ctx context.Context, n domain.GradeNotification) error
+}
+
+func NewSink(cfg config.Notifications) (Sink, error) {
+	switch strings.ToLower(cfg.Sink) {
+	case "", "log":
+		return NewLogSink(), nil
+	case "webhook":
+		if cfg.WebhookURL == "" {
+			return nil, fmt.Errorf("webhook sink requires notifications.webhookURL")
+		}
+		return NewWebhookSink(cfg.WebhookURL, cfg.Timeout), nil
+	case "mail":
+		if cfg.MailDomain == "" {
+			return nil, fmt.Errorf("mail sink requires notifications.mailDomain")
+		}
+		return NewMailSink(cfg.MailFrom, cfg.MailDomain), nil
+	default:
+		return nil, fmt.Errorf("unknown notification sink %q", cfg.Sink)
+	}
+}
+
+type LogSink struct{}
+
+func NewLogSink() *LogSink {
+	return &LogSink{}
+}
+
+func (s *LogSink) Send(_ context.Context, n domain.GradeNotification) error {
+	logger.Logger.Info().
+		Str("kind", string(n.Kind)).
+		Str("login", n.Login).
+		Str("suID", n.SuID).
+		Str("subject", n.Subject).
+		Str("score", n.Score).
+		Str("previous_score", n.PreviousScore).
+		Msg("grade notification")
+	return nil
+}
+
+type WebhookSink struct {
+	client *http.Client
+	url    string
+}
+
+func NewWebhookSink(url string, timeout time.Duration) *WebhookSink {
+	return &WebhookSink{
+		client: &http.Client{
+			Timeout: timeout,
+		},
+		url: url,
+	}
+}
+
+func (s *WebhookSink) Send(ctx context.Context, n domain.GradeNotification) error {
+	body, err := json.Marshal(n)
+	if err != nil {
+		return fmt.Errorf("failed to marshal notification: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "POST", s.url, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to send webhook: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= http.StatusBadRequest {
+		return fmt.Errorf("webhook returned status: %d", resp.StatusCode)
+	}
+
+	return nil
+}
+
+type MailSink struct {
+	from   string
+	domain string
+}
+
+func NewMailSink(from, domain string) *MailSink {
+	return &MailSink{
+		from:   from,
+		domain: domain,
+	}
+}
+
+func (s *MailSink) Send(_ context.Context, n domain.GradeNotification) error {
+	logger.Logger.Info().
+		Str("from", s.from).
+		Str("to", fmt.Sprintf("%s@%s", n.Login, s.domain)).
+		Str("subject", mailSubject(n)).
+		Str("body", mailBody(n)).
+		Msg("grade notification mail queued")
+	return nil
+}
+
+func mailSubject(n domain.GradeNotification) string {
+	if n.Kind == domain.GradeNotificationChanged {
+		return fmt.Sprintf("Изменена оценка: %s", n.Subject)
+	}
+	return fmt.Sprintf("Новая оценка: %s", n.Subject)
+}
+
+func mailBody(n domain.GradeNotification) string {
+	var b strings.Builder
+
+	fmt.Fprintf(&b, "%s (%s)\n", n.Subject, n.WorkType)
+	if n.Description != "" {
+		fmt.Fprintf(&b, "%s\n", n.Description)
+	}
+	fmt.Fprintf(&b, "Оценка: %s из %d\n", n.Score, n.MaxScore)
+	if n.PreviousScore != "" {
+		fmt.Fprintf(&b, "Было: %s\n", n.PreviousScore)
+	}
+	if n.DateP != "" {
+		fmt.Fprintf(&b, "Дата: %s\n", n.DateP)
+	}
+
+	return b.String()
+}
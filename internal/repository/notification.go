@@ -0,0 +1,10 @@
+package repository
+
+type NotificationRepository interface {
+	Subscribe(login string) error
+	Unsubscribe(login string) error
+	IsSubscribed(login string) (bool, error)
+	Subscribers() ([]string, error)
+	SeenScores(login string) (map[string]string, bool, error)
+	SaveSeenScores(login string, seen map[string]string) error
+}
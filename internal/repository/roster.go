@@ -0,0 +1,71 @@
+package repository
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/anton1ks96/college-app-core/internal/domain"
+	"github.com/spf13/viper"
+)
+
+type RosterRepository interface {
+	FetchRoster(group string) ([]domain.RosterStudent, error)
+	FetchCurators(group string) ([]string, error)
+}
+
+type FileRosterRepository struct {
+	path string
+}
+
+type rosterGroup struct {
+	Group    string
+	Curators []string
+	Students []domain.RosterStudent
+}
+
+func NewFileRosterRepository(path string) *FileRosterRepository {
+	return &FileRosterRepository{
+		path: path,
+	}
+}
+
+func (r *FileRosterRepository) FetchRoster(group string) ([]domain.RosterStudent, error) {
+	g, err := r.findGroup(group)
+	if err != nil {
+		return nil, err
+	}
+
+	return g.Students, nil
+}
+
+func (r *FileRosterRepository) FetchCurators(group string) ([]string, error) {
+	g, err := r.findGroup(group)
+	if err != nil {
+		return nil, err
+	}
+
+	return g.Curators, nil
+}
+
+func (r *FileRosterRepository) findGroup(group string) (*rosterGroup, error) {
+	v := viper.New()
+	v.SetConfigFile(r.path)
+	v.SetConfigType("yml")
+
+	if err := v.ReadInConfig(); err != nil {
+		return nil, fmt.Errorf("failed to read roster file: %w", err)
+	}
+
+	var groups []rosterGroup
+	if err := v.UnmarshalKey("groups", &groups); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal roster file: %w", err)
+	}
+
+	for i := range groups {
+		if strings.EqualFold(groups[i].Group, group) {
+			return &groups[i], nil
+		}
+	}
+
+	return nil, fmt.Errorf("group %s not found in roster", group)
+}
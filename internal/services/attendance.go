@@ -2,18 +2,37 @@ package services
 
 import (
 	"fmt"
+	"strconv"
+	"strings"
+	"time"
 
+	"github.com/anton1ks96/college-app-core/internal/config"
 	"github.com/anton1ks96/college-app-core/internal/domain"
 	"github.com/anton1ks96/college-app-core/internal/repository"
+	"github.com/anton1ks96/college-app-core/pkg/logger"
 )
 
 type AttendanceService struct {
-	portal *repository.PortalRepository
+	portal          *repository.PortalRepository
+	schedule        *ScheduleService
+	calendar        *CalendarService
+	bells           *BellService
+	history         *HistoryRecorder
+	statuses        map[int]domain.AttendanceStatus
+	budget          config.AttendanceBudget
+	excusedAsMissed bool
 }
 
-func NewAttendanceService(portal *repository.PortalRepository) *AttendanceService {
+func NewAttendanceService(portal *repository.PortalRepository, schedule *ScheduleService, calendar *CalendarService, bells *BellService, history *HistoryRecorder, cfg config.Attendance) *AttendanceService {
 	return &AttendanceService{
-		portal: portal,
+		portal:          portal,
+		schedule:        schedule,
+		calendar:        calendar,
+		bells:           bells,
+		history:         history,
+		statuses:        parseStatusMapping(cfg.Statuses),
+		budget:          cfg.Budget,
+		excusedAsMissed: cfg.ExcusedAsMissed,
 	}
 }
 
@@ -32,6 +51,7 @@ func (s *AttendanceService) GetAttendance(login, start, end string) ([]domain.At
 		if len(records[i].SubGroup) == 1 {
 			sg := records[i].SubGroup[0]
 			records[i].Title = sg.STitle
+			records[i].SubClID = sg.SClID
 
 			if records[i].Topic == "" {
 				records[i].Topic = sg.STopic
@@ -41,7 +61,50 @@ func (s *AttendanceService) GetAttendance(login, start, end string) ([]domain.At
 			}
 			records[i].SubGroup = nil
 		}
+
+		records[i].StatusName = s.statusOf(records[i].Status)
+		records[i].Period = s.bells.PeriodNumber(records[i].Day, records[i].Start)
 	}
 
+	s.history.RecordAttendance(login, records, time.Now())
+
 	return records, nil
 }
+
+func (s *AttendanceService) countsAsMissed(status domain.AttendanceStatus) bool {
+	return status.Missed() || (s.excusedAsMissed && status == domain.AttendanceStatusExcused)
+}
+
+func (s *AttendanceService) statusOf(code int) domain.AttendanceStatus {
+	if status, ok := s.statuses[code]; ok {
+		return status
+	}
+	return domain.AttendanceStatusUnknown
+}
+
+func parseStatusMapping(raw map[string]string) map[int]domain.AttendanceStatus {
+	statuses := make(map[int]domain.AttendanceStatus, len(raw))
+
+	for key, name := range raw {
+		code, err := strconv.Atoi(key)
+		if err != nil {
+			logger.Warn(fmt.Sprintf("ignoring attendance status mapping with non-numeric code %q", key))
+			continue
+		}
+
+		status := domain.AttendanceStatus(strings.ToLower(name))
+		switch status {
+		case domain.AttendanceStatusPresent, domain.AttendanceStatusAbsent, domain.AttendanceStatusLate,
+			domain.AttendanceStatusExcused, domain.AttendanceStatusNotMarked:
+			statuses[code] = status
+		default:
+			logger.Warn(fmt.Sprintf("ignoring unknown attendance status %q for code %d", name, code))
+		}
+	}
+
+	if len(statuses) == 0 {
+		statuses[2] = domain.AttendanceStatusPresent
+	}
+
+	return statuses
+}
@@ -0,0 +1,164 @@
+package services
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/anton1ks96/college-app-core/internal/domain"
+)
+
+const defaultAttendanceThreshold = 0.7
+
+func (s *AttendanceService) GetAbsenceBudget(login string, sel domain.ScheduleSelection) (*domain.AbsenceBudgetResponse, error) {
+	now := time.Now()
+	today := now.Format(dateLayout)
+
+	termStart, termEnd, err := s.calendar.ResolvePeriod("semester", now)
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve current term: %w", err)
+	}
+
+	historyEnd := today
+	if termEnd < historyEnd {
+		historyEnd = termEnd
+	}
+
+	records := make([]domain.AttendanceRecord, 0)
+	if termStart <= historyEnd {
+		records, err = s.GetAttendance(login, termStart, historyEnd)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	events := make([]domain.ScheduleEvent, 0)
+	scheduleStart := today
+	if termStart > scheduleStart {
+		scheduleStart = termStart
+	}
+	if scheduleStart <= termEnd {
+		events, err = s.schedule.GetSchedule(sel.Group, sel.Subgroup, sel.EnglishGroup, sel.ProfileSubgroup, scheduleStart, termEnd)
+		if err != nil {
+			return nil, err
+		}
+	}
+	remaining := remainingLessons(events, records, today, s.calendar.IsStudyDay)
+
+	return &domain.AbsenceBudgetResponse{
+		PeriodStart: termStart,
+		PeriodEnd:   termEnd,
+		Subjects:    s.calculateBudget(records, remaining),
+	}, nil
+}
+
+func (s *AttendanceService) calculateBudget(records []domain.AttendanceRecord, remaining map[string]int) []domain.SubjectAbsenceBudget {
+	bySubject := make(map[string]*domain.SubjectAbsenceBudget)
+	subject := func(title string) *domain.SubjectAbsenceBudget {
+		title = strings.TrimSpace(title)
+		key := strings.ToLower(title)
+		b, ok := bySubject[key]
+		if !ok {
+			b = &domain.SubjectAbsenceBudget{
+				Title:     title,
+				Threshold: s.thresholdFor(title),
+			}
+			bySubject[key] = b
+		}
+		return b
+	}
+
+	for _, r := range records {
+		switch {
+		case r.StatusName.Attended():
+			subject(attendanceLessonTitle(r)).Attended++
+		case s.countsAsMissed(r.StatusName):
+			subject(attendanceLessonTitle(r)).Missed++
+		}
+	}
+	for title, count := range remaining {
+		subject(title).Remaining += count
+	}
+
+	result := make([]domain.SubjectAbsenceBudget, 0, len(bySubject))
+	for _, b := range bySubject {
+		held := b.Attended + b.Missed
+		total := held + b.Remaining
+
+		if held > 0 {
+			b.AttendanceRate = float64(b.Attended) / float64(held)
+		}
+
+		canMiss := int(math.Floor(float64(b.Attended+b.Remaining) - b.Threshold*float64(total) + 1e-9))
+		if canMiss < 0 {
+			b.Unreachable = true
+			canMiss = 0
+		}
+		b.CanMiss = canMiss
+		b.AtRisk = b.Unreachable || (held > 0 && b.AttendanceRate < b.Threshold)
+
+		result = append(result, *b)
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].AtRisk != result[j].AtRisk {
+			return result[i].AtRisk
+		}
+		if result[i].CanMiss != result[j].CanMiss {
+			return result[i].CanMiss < result[j].CanMiss
+		}
+		return result[i].Title < result[j].Title
+	})
+
+	return result
+}
+
+func remainingLessons(events []domain.ScheduleEvent, records []domain.AttendanceRecord, today string, isStudyDay func(day string) bool) map[string]int {
+	marked := make(map[string]bool)
+	for _, r := range records {
+		if r.Day == today && r.StatusName.Marked() {
+			marked[reconcileKey(r.Day, strconv.Itoa(r.ClID))] = true
+		}
+	}
+
+	remaining := make(map[string]int)
+	for _, ev := range events {
+		if ev.Day < today || !isStudyDay(ev.Day) {
+			continue
+		}
+		if ev.Day == today && marked[reconcileKey(ev.Day, ev.ClID)] {
+			continue
+		}
+		remaining[scheduleLessonTitle(ev)]++
+	}
+	return remaining
+}
+
+func scheduleLessonTitle(ev domain.ScheduleEvent) string {
+	if len(ev.SubGroup) == 1 {
+		return ev.SubGroup[0].STitle
+	}
+	return ev.Title
+}
+
+func attendanceLessonTitle(r domain.AttendanceRecord) string {
+	if len(r.SubGroup) == 1 {
+		return r.SubGroup[0].STitle
+	}
+	return r.Title
+}
+
+func (s *AttendanceService) thresholdFor(title string) float64 {
+	for _, st := range s.budget.Subjects {
+		if strings.EqualFold(st.Title, title) {
+			return st.Threshold
+		}
+	}
+	if s.budget.Threshold > 0 {
+		return s.budget.Threshold
+	}
+	return defaultAttendanceThreshold
+}
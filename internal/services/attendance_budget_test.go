@@ -0,0 +1,121 @@
+package services
+
+import (
+	"testing"
+
+	"github.com/anton1ks96/college-app-core/internal/config"
+	"github.com/anton1ks96/college-app-core/internal/domain"
+)
+
+func attendanceRecords(title string, statuses ...domain.AttendanceStatus) []domain.AttendanceRecord {
+	records := make([]domain.AttendanceRecord, len(statuses))
+	for i, status := range statuses {
+		records[i] = domain.AttendanceRecord{ClID: i + 1, Day: "2026-09-07", Title: title, StatusName: status}
+	}
+	return records
+}
+
+func TestCalculateBudget(t *testing.T) {
+	const (
+		present = domain.AttendanceStatusPresent
+		late    = domain.AttendanceStatusLate
+		absent  = domain.AttendanceStatusAbsent
+		excused = domain.AttendanceStatusExcused
+	)
+
+	tests := []struct {
+		name            string
+		budget          config.AttendanceBudget
+		excusedAsMissed bool
+		records         []domain.AttendanceRecord
+		remaining       int
+		want            domain.SubjectAbsenceBudget
+	}{
+		{
+			name:      "room to miss",
+			records:   attendanceRecords("Math", present, present, present, present, present, late, absent),
+			remaining: 3,
+			want:      domain.SubjectAbsenceBudget{Title: "Math", Threshold: 0.7, Attended: 6, Missed: 1, Remaining: 3, AttendanceRate: 6.0 / 7, CanMiss: 2},
+		},
+		{
+			name:      "exactly on threshold",
+			records:   attendanceRecords("Math", present, present, present, present, present, present, present, absent, absent, absent),
+			remaining: 0,
+			want:      domain.SubjectAbsenceBudget{Title: "Math", Threshold: 0.7, Attended: 7, Missed: 3, AttendanceRate: 0.7, CanMiss: 0},
+		},
+		{
+			name:      "unreachable",
+			records:   attendanceRecords("Math", present, present, absent, absent, absent, absent, absent),
+			remaining: 3,
+			want:      domain.SubjectAbsenceBudget{Title: "Math", Threshold: 0.7, Attended: 2, Missed: 5, Remaining: 3, AttendanceRate: 2.0 / 7, AtRisk: true, Unreachable: true},
+		},
+		{
+			name:      "only future lessons",
+			remaining: 10,
+			want:      domain.SubjectAbsenceBudget{Title: "Math", Threshold: 0.7, Remaining: 10, CanMiss: 3},
+		},
+		{
+			name:      "excused ignored by default",
+			records:   attendanceRecords("Math", present, excused, excused),
+			remaining: 2,
+			want:      domain.SubjectAbsenceBudget{Title: "Math", Threshold: 0.7, Attended: 1, Remaining: 2, AttendanceRate: 1, CanMiss: 0},
+		},
+		{
+			name:            "excused counted as missed",
+			excusedAsMissed: true,
+			records:         attendanceRecords("Math", present, excused, excused),
+			remaining:       2,
+			want:            domain.SubjectAbsenceBudget{Title: "Math", Threshold: 0.7, Attended: 1, Missed: 2, Remaining: 2, AttendanceRate: 1.0 / 3, AtRisk: true, Unreachable: true},
+		},
+		{
+			name:      "per-subject threshold",
+			budget:    config.AttendanceBudget{Threshold: 0.5, Subjects: []config.SubjectThreshold{{Title: "math", Threshold: 0.9}}},
+			records:   attendanceRecords("Math", present, present, present, present, present, present, present, present, present),
+			remaining: 1,
+			want:      domain.SubjectAbsenceBudget{Title: "Math", Threshold: 0.9, Attended: 9, Remaining: 1, AttendanceRate: 1, CanMiss: 1},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc := &AttendanceService{budget: tt.budget, excusedAsMissed: tt.excusedAsMissed}
+			got := svc.calculateBudget(tt.records, map[string]int{"Math": tt.remaining})
+			if len(got) != 1 {
+				t.Fatalf("got %d subjects, want 1: %+v", len(got), got)
+			}
+			if got[0] != tt.want {
+				t.Errorf("budget = %+v, want %+v", got[0], tt.want)
+			}
+		})
+	}
+}
+
+func TestRemainingLessons(t *testing.T) {
+	events := []domain.ScheduleEvent{
+		{ClID: "1", Day: "2026-09-08", Title: "Math"},
+		{ClID: "2", Day: "2026-09-09", Title: "Math"},
+		{ClID: "3", Day: "2026-09-09", Title: "Physics"},
+		{ClID: "4", Day: "2026-09-09", Title: "History"},
+		{ClID: "5", Day: "2026-09-10", Title: "Math"},
+		{ClID: "6", Day: "2026-09-11", Title: "Math"},
+		{ClID: "7", Day: "2026-09-10", Title: "Lab", SubGroup: []domain.SubGroup{{SClID: "71", STitle: "Physics"}}},
+	}
+	records := []domain.AttendanceRecord{
+		{ClID: 1, Day: "2026-09-08", Title: "Math", StatusName: domain.AttendanceStatusPresent},
+		{ClID: 2, Day: "2026-09-09", Title: "Math", StatusName: domain.AttendanceStatusPresent},
+		{ClID: 3, Day: "2026-09-09", Title: "Physics", StatusName: domain.AttendanceStatusNotMarked},
+	}
+	isStudyDay := func(day string) bool { return day != "2026-09-11" }
+
+	got := remainingLessons(events, records, "2026-09-09", isStudyDay)
+	want := map[string]int{"Math": 1, "Physics": 2, "History": 1}
+
+	if len(got) != len(want) {
+		t.Fatalf("remaining = %v, want %v", got, want)
+	}
+	for title, count := range want {
+		if got[title] != count {
+			t.Errorf("remaining[%s] = %d, want %d", title, got[title], count)
+		}
+	}
+}
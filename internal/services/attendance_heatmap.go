@@ -0,0 +1,103 @@
+package services
+
+import (
+	"math"
+
+	"github.com/anton1ks96/college-app-core/internal/domain"
+)
+
+var statusPriority = []domain.AttendanceStatus{
+	domain.AttendanceStatusAbsent,
+	domain.AttendanceStatusExcused,
+	domain.AttendanceStatusLate,
+	domain.AttendanceStatusPresent,
+	domain.AttendanceStatusNotMarked,
+	domain.AttendanceStatusUnknown,
+}
+
+func (s *AttendanceService) GetAttendanceHeatmap(login string, sel domain.ScheduleSelection, start, end string) (*domain.AttendanceHeatmapResponse, error) {
+	days, err := s.calendar.Days(start, end)
+	if err != nil {
+		return nil, err
+	}
+
+	events, err := s.schedule.GetSchedule(sel.Group, sel.Subgroup, sel.EnglishGroup, sel.ProfileSubgroup, start, end)
+	if err != nil {
+		return nil, err
+	}
+
+	records, err := s.GetAttendance(login, start, end)
+	if err != nil {
+		return nil, err
+	}
+
+	return &domain.AttendanceHeatmapResponse{
+		PeriodStart: start,
+		PeriodEnd:   end,
+		Days:        s.buildHeatmap(days, events, records),
+	}, nil
+}
+
+func (s *AttendanceService) buildHeatmap(days []domain.CalendarDay, events []domain.ScheduleEvent, records []domain.AttendanceRecord) []domain.HeatmapDay {
+	scheduled := make(map[string]int)
+	for _, ev := range events {
+		scheduled[ev.Day]++
+	}
+
+	byDay := make(map[string][]domain.AttendanceRecord)
+	for _, r := range records {
+		byDay[r.Day] = append(byDay[r.Day], r)
+	}
+
+	result := make([]domain.HeatmapDay, 0, len(days))
+	maxAttended := 0
+
+	for _, day := range days {
+		entry := domain.HeatmapDay{
+			Date:      day.Date,
+			Weekday:   day.Weekday,
+			Kind:      day.Kind,
+			Name:      day.Name,
+			Scheduled: scheduled[day.Date],
+		}
+
+		counts := make(map[domain.AttendanceStatus]int)
+		for _, r := range byDay[day.Date] {
+			switch {
+			case r.StatusName.Attended():
+				entry.Attended++
+			case s.countsAsMissed(r.StatusName):
+				entry.Missed++
+			}
+			counts[r.StatusName]++
+		}
+		entry.DominantStatus = dominantStatus(counts)
+
+		if entry.Attended > maxAttended {
+			maxAttended = entry.Attended
+		}
+		result = append(result, entry)
+	}
+
+	if maxAttended > 0 {
+		for i := range result {
+			result[i].Intensity = math.Round(float64(result[i].Attended)/float64(maxAttended)*100) / 100
+		}
+	}
+
+	return result
+}
+
+func dominantStatus(counts map[domain.AttendanceStatus]int) domain.AttendanceStatus {
+	var dominant domain.AttendanceStatus
+	best := 0
+
+	for _, status := range statusPriority {
+		if counts[status] > best {
+			dominant = status
+			best = counts[status]
+		}
+	}
+
+	return dominant
+}
@@ -0,0 +1,71 @@
+package services
+
+import (
+	"testing"
+
+	"github.com/anton1ks96/college-app-core/internal/domain"
+)
+
+func TestBuildHeatmap(t *testing.T) {
+	days := []domain.CalendarDay{
+		{Date: "2026-09-07", Kind: domain.DayKindStudy},
+		{Date: "2026-09-08", Kind: domain.DayKindStudy},
+		{Date: "2026-09-09", Kind: domain.DayKindStudy},
+		{Date: "2026-09-13", Kind: domain.DayKindWeekend},
+	}
+	events := []domain.ScheduleEvent{
+		{ClID: "1", Day: "2026-09-07"},
+		{ClID: "2", Day: "2026-09-07"},
+		{ClID: "3", Day: "2026-09-07"},
+		{ClID: "4", Day: "2026-09-08"},
+		{ClID: "5", Day: "2026-09-08"},
+		{ClID: "6", Day: "2026-09-09"},
+	}
+	records := []domain.AttendanceRecord{
+		{ClID: 1, Day: "2026-09-07", StatusName: domain.AttendanceStatusPresent},
+		{ClID: 2, Day: "2026-09-07", StatusName: domain.AttendanceStatusLate},
+		{ClID: 4, Day: "2026-09-08", StatusName: domain.AttendanceStatusAbsent},
+		{ClID: 5, Day: "2026-09-08", StatusName: domain.AttendanceStatusExcused},
+	}
+
+	tests := []struct {
+		name            string
+		excusedAsMissed bool
+		want            []domain.HeatmapDay
+	}{
+		{
+			name: "excused not missed",
+			want: []domain.HeatmapDay{
+				{Date: "2026-09-07", Kind: domain.DayKindStudy, Scheduled: 3, Attended: 2, DominantStatus: domain.AttendanceStatusLate, Intensity: 1},
+				{Date: "2026-09-08", Kind: domain.DayKindStudy, Scheduled: 2, Missed: 1, DominantStatus: domain.AttendanceStatusAbsent},
+				{Date: "2026-09-09", Kind: domain.DayKindStudy, Scheduled: 1},
+				{Date: "2026-09-13", Kind: domain.DayKindWeekend},
+			},
+		},
+		{
+			name:            "excused as missed",
+			excusedAsMissed: true,
+			want: []domain.HeatmapDay{
+				{Date: "2026-09-07", Kind: domain.DayKindStudy, Scheduled: 3, Attended: 2, DominantStatus: domain.AttendanceStatusLate, Intensity: 1},
+				{Date: "2026-09-08", Kind: domain.DayKindStudy, Scheduled: 2, Missed: 2, DominantStatus: domain.AttendanceStatusAbsent},
+				{Date: "2026-09-09", Kind: domain.DayKindStudy, Scheduled: 1},
+				{Date: "2026-09-13", Kind: domain.DayKindWeekend},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc := &AttendanceService{excusedAsMissed: tt.excusedAsMissed}
+			got := svc.buildHeatmap(days, events, records)
+			if len(got) != len(tt.want) {
+				t.Fatalf("got %d days, want %d", len(got), len(tt.want))
+			}
+			for i := range tt.want {
+				if got[i] != tt.want[i] {
+					t.Errorf("day %d = %+v, want %+v", i, got[i], tt.want[i])
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,77 @@
+package services
+
+import (
+	"math"
+	"sort"
+
+	"github.com/anton1ks96/college-app-core/internal/domain"
+)
+
+func (s *AttendanceService) GetAttendanceStats(login, start, end string) (*domain.AttendanceStatsResponse, error) {
+	records, err := s.GetAttendance(login, start, end)
+	if err != nil {
+		return nil, err
+	}
+
+	return &domain.AttendanceStatsResponse{
+		PeriodStart: start,
+		PeriodEnd:   end,
+		Subjects:    s.calculateSubjectStats(records),
+	}, nil
+}
+
+func (s *AttendanceService) calculateSubjectStats(records []domain.AttendanceRecord) []domain.SubjectAttendanceStats {
+	bySubject := make(map[string]*domain.SubjectAttendanceStats)
+
+	for _, r := range records {
+		stats, ok := bySubject[r.Title]
+		if !ok {
+			stats = &domain.SubjectAttendanceStats{
+				Title:    r.Title,
+				ByStatus: make(map[string]int),
+			}
+			bySubject[r.Title] = stats
+		}
+
+		stats.Total++
+		stats.ByStatus[string(r.StatusName)]++
+
+		switch {
+		case r.StatusName.Attended():
+			stats.Attended++
+		case s.countsAsMissed(r.StatusName):
+			stats.Missed++
+			stats.HoursMissed += classHours(r.Start, r.End)
+		}
+	}
+
+	result := make([]domain.SubjectAttendanceStats, 0, len(bySubject))
+	for _, stats := range bySubject {
+		if marked := stats.Attended + stats.Missed; marked > 0 {
+			stats.AttendanceRate = float64(stats.Attended) / float64(marked)
+		}
+		stats.HoursMissed = math.Round(stats.HoursMissed*100) / 100
+		result = append(result, *stats)
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].AttendanceRate != result[j].AttendanceRate {
+			return result[i].AttendanceRate < result[j].AttendanceRate
+		}
+		if result[i].Missed != result[j].Missed {
+			return result[i].Missed > result[j].Missed
+		}
+		return result[i].Title < result[j].Title
+	})
+
+	return result
+}
+
+func classHours(start, end string) float64 {
+	from, okStart := parseClock(start)
+	to, okEnd := parseClock(end)
+	if !okStart || !okEnd || to <= from {
+		return 0
+	}
+	return float64(to-from) / 60
+}
@@ -0,0 +1,97 @@
+package services
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/anton1ks96/college-app-core/internal/config"
+	"github.com/anton1ks96/college-app-core/internal/domain"
+)
+
+const defaultBellSchedule = "default"
+
+type BellService struct {
+	bells config.Bells
+}
+
+func NewBellService(bells config.Bells) *BellService {
+	return &BellService{
+		bells: bells,
+	}
+}
+
+func (s *BellService) GetBells(date string) (*domain.BellSchedule, error) {
+	day, err := time.ParseInLocation(dateLayout, date, time.Local)
+	if err != nil {
+		return nil, fmt.Errorf("invalid date %q", date)
+	}
+
+	name, periods := s.periodsFor(day)
+
+	result := &domain.BellSchedule{
+		Date:     day.Format(dateLayout),
+		Schedule: name,
+		Periods:  make([]domain.BellPeriod, 0, len(periods)),
+	}
+	for _, p := range periods {
+		result.Periods = append(result.Periods, domain.BellPeriod{
+			Number: p.Number,
+			Start:  p.Start,
+			End:    p.End,
+		})
+	}
+
+	return result, nil
+}
+
+func (s *BellService) PeriodNumber(date, start string) int {
+	minutes, ok := parseClock(start)
+	if !ok {
+		return 0
+	}
+
+	periods := s.bells.Periods
+	if day, err := time.ParseInLocation(dateLayout, date, time.Local); err == nil {
+		_, periods = s.periodsFor(day)
+	}
+
+	containing := 0
+	for _, p := range periods {
+		pStart, okStart := parseClock(p.Start)
+		pEnd, okEnd := parseClock(p.End)
+		if !okStart || !okEnd {
+			continue
+		}
+		if minutes == pStart {
+			return p.Number
+		}
+		if containing == 0 && minutes > pStart && minutes < pEnd {
+			containing = p.Number
+		}
+	}
+
+	return containing
+}
+
+func (s *BellService) periodsFor(day time.Time) (string, []config.BellPeriod) {
+	date := day.Format(dateLayout)
+
+	for _, alt := range s.bells.Alternates {
+		for _, d := range alt.Dates {
+			if d == date {
+				return alt.Name, alt.Periods
+			}
+		}
+	}
+
+	for _, alt := range s.bells.Alternates {
+		for _, wd := range alt.Weekdays {
+			if strings.EqualFold(wd, day.Weekday().String()) {
+				return alt.Name, alt.Periods
+			}
+		}
+	}
+
+	return defaultBellSchedule, s.bells.Periods
+}
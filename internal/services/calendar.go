@@ -0,0 +1,164 @@
+package services
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/anton1ks96/college-app-core/internal/calendar"
+	"github.com/anton1ks96/college-app-core/internal/domain"
+)
+
+const (
+	dateLayout      = "2006-01-02"
+	maxCalendarDays = 366
+)
+
+type CalendarService struct {
+	calendar *calendar.Calendar
+}
+
+func NewCalendarService(cal *calendar.Calendar) *CalendarService {
+	return &CalendarService{
+		calendar: cal,
+	}
+}
+
+func (s *CalendarService) GetCalendar(start, end string) (*domain.AcademicCalendar, error) {
+	result := s.calendar.Source()
+
+	if start != "" || end != "" {
+		days, err := s.Days(start, end)
+		if err != nil {
+			return nil, err
+		}
+		result.Days = days
+	}
+
+	return &result, nil
+}
+
+func (s *CalendarService) Days(start, end string) ([]domain.CalendarDay, error) {
+	from, to, err := parseRange(start, end)
+	if err != nil {
+		return nil, err
+	}
+	if to.Sub(from) > maxCalendarDays*24*time.Hour {
+		return nil, fmt.Errorf("date range must not exceed %d days", maxCalendarDays)
+	}
+
+	days := make([]domain.CalendarDay, 0)
+	for day := from; !day.After(to); day = day.AddDate(0, 0, 1) {
+		days = append(days, s.calendar.Day(day))
+	}
+
+	return days, nil
+}
+
+func (s *CalendarService) IsStudyDay(date string) bool {
+	day, err := time.ParseInLocation(dateLayout, date, time.Local)
+	if err != nil {
+		return true
+	}
+	return s.calendar.IsStudyDay(day)
+}
+
+func (s *CalendarService) AcademicYearStart(now time.Time) (time.Time, error) {
+	year, ok := s.calendar.YearAt(now)
+	if !ok {
+		return time.Time{}, fmt.Errorf("no academic year in calendar covers %s", now.Format(dateLayout))
+	}
+
+	return time.ParseInLocation(dateLayout, year.Start, time.Local)
+}
+
+func (s *CalendarService) ResolveWeek(spec string, now time.Time) (string, string, error) {
+	today := truncateDay(now)
+
+	var monday time.Time
+	switch strings.ToLower(spec) {
+	case "current":
+		monday = weekStart(today)
+	case "next":
+		monday = weekStart(today).AddDate(0, 0, 7)
+	default:
+		var err error
+		monday, err = parseISOWeek(spec)
+		if err != nil {
+			return "", "", err
+		}
+	}
+
+	return monday.Format(dateLayout), monday.AddDate(0, 0, 6).Format(dateLayout), nil
+}
+
+func (s *CalendarService) ResolvePeriod(spec string, now time.Time) (string, string, error) {
+	today := truncateDay(now)
+
+	switch strings.ToLower(spec) {
+	case "year":
+		year, ok := s.calendar.YearAt(today)
+		if !ok {
+			return "", "", fmt.Errorf("no academic year in calendar covers %s", today.Format(dateLayout))
+		}
+		return year.Start, year.End, nil
+	case "semester":
+		term, ok := s.calendar.TermAt(today)
+		if !ok {
+			return "", "", fmt.Errorf("no academic term in calendar covers %s", today.Format(dateLayout))
+		}
+		return term.Start, term.End, nil
+	default:
+		return "", "", fmt.Errorf("unknown period %q: expected semester or year", spec)
+	}
+}
+
+func parseRange(start, end string) (time.Time, time.Time, error) {
+	from, err := time.ParseInLocation(dateLayout, start, time.Local)
+	if err != nil {
+		return time.Time{}, time.Time{}, fmt.Errorf("invalid start date %q", start)
+	}
+	to, err := time.ParseInLocation(dateLayout, end, time.Local)
+	if err != nil {
+		return time.Time{}, time.Time{}, fmt.Errorf("invalid end date %q", end)
+	}
+	if to.Before(from) {
+		return time.Time{}, time.Time{}, fmt.Errorf("end date is before start date")
+	}
+	return from, to, nil
+}
+
+func parseISOWeek(spec string) (time.Time, error) {
+	yearPart, weekPart, ok := strings.Cut(strings.ToUpper(spec), "-W")
+	if !ok {
+		return time.Time{}, fmt.Errorf("invalid week %q: expected current, next or YYYY-Www", spec)
+	}
+
+	year, err := strconv.Atoi(yearPart)
+	if err != nil || len(yearPart) != 4 {
+		return time.Time{}, fmt.Errorf("invalid week year in %q", spec)
+	}
+	week, err := strconv.Atoi(weekPart)
+	if err != nil || len(weekPart) != 2 || week < 1 || week > 53 {
+		return time.Time{}, fmt.Errorf("invalid week number in %q", spec)
+	}
+
+	jan4 := time.Date(year, time.January, 4, 0, 0, 0, 0, time.Local)
+	monday := weekStart(jan4).AddDate(0, 0, (week-1)*7)
+
+	if y, w := monday.ISOWeek(); y != year || w != week {
+		return time.Time{}, fmt.Errorf("week %d does not exist in %d", week, year)
+	}
+
+	return monday, nil
+}
+
+func weekStart(day time.Time) time.Time {
+	offset := (int(day.Weekday()) + 6) % 7
+	return day.AddDate(0, 0, -offset)
+}
+
+func truncateDay(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.Local)
+}
@@ -0,0 +1,127 @@
+package services
+
+import (
+	"testing"
+	"time"
+
+	"github.com/anton1ks96/college-app-core/internal/calendar"
+	"github.com/anton1ks96/college-app-core/internal/domain"
+)
+
+func testCalendarService(t *testing.T) *CalendarService {
+	t.Helper()
+
+	cal, err := calendar.New(domain.AcademicCalendar{
+		Terms: []domain.CalendarPeriod{
+			{Name: "autumn", Start: "2026-09-01", End: "2026-12-29"},
+			{Name: "spring", Start: "2027-01-11", End: "2027-06-30"},
+		},
+	})
+	if err != nil {
+		t.Fatalf("calendar.New: %v", err)
+	}
+	return NewCalendarService(cal)
+}
+
+func mustDate(t *testing.T, value string) time.Time {
+	t.Helper()
+
+	d, err := time.ParseInLocation(dateLayout, value, time.Local)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return d
+}
+
+func TestResolvePeriod(t *testing.T) {
+	svc := testCalendarService(t)
+
+	tests := []struct {
+		spec    string
+		now     string
+		start   string
+		end     string
+		wantErr bool
+	}{
+		{spec: "semester", now: "2026-10-15", start: "2026-09-01", end: "2026-12-29"},
+		{spec: "SEMESTER", now: "2027-03-01", start: "2027-01-11", end: "2027-06-30"},
+		{spec: "semester", now: "2027-01-05", wantErr: true},
+		{spec: "semester", now: "2027-08-01", wantErr: true},
+		{spec: "year", now: "2027-01-05", start: "2026-09-01", end: "2027-06-30"},
+		{spec: "year", now: "2026-09-01", start: "2026-09-01", end: "2027-06-30"},
+		{spec: "year", now: "2026-08-31", wantErr: true},
+		{spec: "quarter", now: "2026-10-15", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.spec+"@"+tt.now, func(t *testing.T) {
+			start, end, err := svc.ResolvePeriod(tt.spec, mustDate(t, tt.now))
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("ResolvePeriod = %s..%s, want error", start, end)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ResolvePeriod: %v", err)
+			}
+			if start != tt.start || end != tt.end {
+				t.Errorf("ResolvePeriod = %s..%s, want %s..%s", start, end, tt.start, tt.end)
+			}
+		})
+	}
+}
+
+func TestAcademicYearStart(t *testing.T) {
+	svc := testCalendarService(t)
+
+	start, err := svc.AcademicYearStart(mustDate(t, "2027-02-10"))
+	if err != nil {
+		t.Fatalf("AcademicYearStart: %v", err)
+	}
+	if got := start.Format(dateLayout); got != "2026-09-01" {
+		t.Errorf("AcademicYearStart = %s, want 2026-09-01", got)
+	}
+
+	if _, err := svc.AcademicYearStart(mustDate(t, "2027-07-20")); err == nil {
+		t.Error("AcademicYearStart outside configured year did not fail")
+	}
+}
+
+func TestResolveWeek(t *testing.T) {
+	svc := testCalendarService(t)
+	now := mustDate(t, "2026-09-09")
+
+	tests := []struct {
+		spec    string
+		start   string
+		end     string
+		wantErr bool
+	}{
+		{spec: "current", start: "2026-09-07", end: "2026-09-13"},
+		{spec: "next", start: "2026-09-14", end: "2026-09-20"},
+		{spec: "2026-W01", start: "2025-12-29", end: "2026-01-04"},
+		{spec: "2026-w53", start: "2026-12-28", end: "2027-01-03"},
+		{spec: "2027-W53", wantErr: true},
+		{spec: "2026-W1", wantErr: true},
+		{spec: "2026W10", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.spec, func(t *testing.T) {
+			start, end, err := svc.ResolveWeek(tt.spec, now)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("ResolveWeek = %s..%s, want error", start, end)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ResolveWeek: %v", err)
+			}
+			if start != tt.start || end != tt.end {
+				t.Errorf("ResolveWeek = %s..%s, want %s..%s", start, end, tt.start, tt.end)
+			}
+		})
+	}
+}
@@ -0,0 +1,116 @@
+package services
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/anton1ks96/college-app-core/internal/config"
+	"github.com/anton1ks96/college-app-core/internal/domain"
+	"github.com/anton1ks96/college-app-core/internal/repository"
+)
+
+type classDetailsEntry struct {
+	details   map[string]any
+	expiresAt time.Time
+}
+
+type ClassDetailsService struct {
+	portal    *repository.PortalRepository
+	workers   int
+	ttl       time.Duration
+	mu        sync.Mutex
+	cache     map[string]classDetailsEntry
+	lastSweep time.Time
+}
+
+func NewClassDetailsService(portal *repository.PortalRepository, cfg config.ClassDetails) *ClassDetailsService {
+	return &ClassDetailsService{
+		portal:  portal,
+		workers: cfg.Workers,
+		ttl:     cfg.CacheTTL,
+		cache:   make(map[string]classDetailsEntry),
+	}
+}
+
+func (s *ClassDetailsService) GetClassDetails(clid string) (map[string]any, error) {
+	if details, ok := s.cached(clid); ok {
+		return details, nil
+	}
+
+	details, err := s.portal.FetchClassDetails(clid)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch class details: %w", err)
+	}
+
+	s.store(clid, details)
+	return details, nil
+}
+
+func (s *ClassDetailsService) GetClassDetailsBatch(clids []string) *domain.ClassDetailsBatchResponse {
+	unique := make([]string, 0, len(clids))
+	seen := make(map[string]bool, len(clids))
+	for _, clid := range clids {
+		if !seen[clid] {
+			seen[clid] = true
+			unique = append(unique, clid)
+		}
+	}
+
+	results := make([]domain.ClassDetailsResult, len(unique))
+	runBounded(len(unique), s.workers, func(i int) {
+		details, err := s.GetClassDetails(unique[i])
+		if err != nil {
+			results[i].Error = err.Error()
+			return
+		}
+		results[i].Details = details
+	})
+
+	resp := &domain.ClassDetailsBatchResponse{
+		Items: make(map[string]domain.ClassDetailsResult, len(unique)),
+	}
+	for i, clid := range unique {
+		if results[i].Error != "" {
+			resp.Failed++
+		}
+		resp.Items[clid] = results[i]
+	}
+
+	return resp
+}
+
+func (s *ClassDetailsService) cached(clid string) (map[string]any, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	entry, ok := s.cache[clid]
+	if !ok || time.Now().After(entry.expiresAt) {
+		return nil, false
+	}
+	return entry.details, true
+}
+
+func (s *ClassDetailsService) store(clid string, details map[string]any) {
+	if s.ttl <= 0 {
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := time.Now()
+	if now.Sub(s.lastSweep) > s.ttl {
+		for key, entry := range s.cache {
+			if now.After(entry.expiresAt) {
+				delete(s.cache, key)
+			}
+		}
+		s.lastSweep = now
+	}
+
+	s.cache[clid] = classDetailsEntry{
+		details:   details,
+		expiresAt: now.Add(s.ttl),
+	}
+}
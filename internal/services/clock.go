@@ -0,0 +1,23 @@
+package services
+
+import (
+	"strings"
+	"time"
+)
+
+func parseClock(value string) (int, bool) {
+	value = strings.TrimSpace(value)
+	if strings.Contains(value, " ") {
+		parts := strings.Split(value, " ")
+		value = parts[len(parts)-1]
+	}
+
+	for _, layout := range []string{"15:04:05", "15:04"} {
+		t, err := time.Parse(layout, value)
+		if err == nil {
+			return t.Hour()*60 + t.Minute(), true
+		}
+	}
+
+	return 0, false
+}
@@ -0,0 +1,255 @@
+package services
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/anton1ks96/college-app-core/internal/domain"
+	"github.com/anton1ks96/college-app-core/internal/storage"
+	"github.com/anton1ks96/college-app-core/pkg/logger"
+)
+
+type CustomizationService struct {
+	store    storage.Storage
+	schedule *ScheduleService
+}
+
+func NewCustomizationService(store storage.Storage, schedule *ScheduleService) *CustomizationService {
+	return &CustomizationService{
+		store:    store,
+		schedule: schedule,
+	}
+}
+
+func (s *CustomizationService) GetCustomization(login string) (*domain.ScheduleCustomization, error) {
+	customization, ok, err := s.store.Customization(login)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load customization: %w", err)
+	}
+	if !ok {
+		customization = &domain.ScheduleCustomization{}
+	}
+	if customization.Subjects == nil {
+		customization.Subjects = make([]domain.SubjectCustomization, 0)
+	}
+	if customization.HiddenSubgroups == nil {
+		customization.HiddenSubgroups = make([]string, 0)
+	}
+	if customization.HiddenEvents == nil {
+		customization.HiddenEvents = make([]string, 0)
+	}
+
+	return customization, nil
+}
+
+func (s *CustomizationService) UpdateCustomization(login string, customization domain.ScheduleCustomization) (*domain.ScheduleCustomization, error) {
+	subjects := make([]domain.SubjectCustomization, 0, len(customization.Subjects))
+	seen := make(map[string]bool, len(customization.Subjects))
+	for _, subject := range customization.Subjects {
+		subject.Title = strings.TrimSpace(subject.Title)
+		subject.Alias = strings.TrimSpace(subject.Alias)
+		key := strings.ToLower(subject.Title)
+		if seen[key] {
+			continue
+		}
+		seen[key] = true
+		subjects = append(subjects, subject)
+	}
+
+	customization.Subjects = subjects
+	customization.HiddenSubgroups = normalizeTitles(customization.HiddenSubgroups)
+	customization.HiddenEvents = normalizeTitles(customization.HiddenEvents)
+	customization.UpdatedAt = time.Now()
+
+	if err := s.store.SaveCustomization(login, customization); err != nil {
+		return nil, fmt.Errorf("failed to save customization: %w", err)
+	}
+
+	return &customization, nil
+}
+
+func (s *CustomizationService) CustomizeSchedule(login string, events []domain.ScheduleEvent) ([]domain.ScheduleEvent, error) {
+	customization, ok, err := s.store.Customization(login)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load customization: %w", err)
+	}
+	if !ok {
+		return events, nil
+	}
+
+	return applyScheduleCustomization(events, customization), nil
+}
+
+func (s *CustomizationService) CustomizeAttendance(login, start, end string, records []domain.AttendanceRecord) ([]domain.AttendanceRecord, error) {
+	customization, ok, err := s.store.Customization(login)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load customization: %w", err)
+	}
+	if !ok {
+		customization = &domain.ScheduleCustomization{}
+	}
+
+	prefs, ok, err := s.store.Preferences(login)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load preferences: %w", err)
+	}
+	if !ok {
+		prefs = &domain.UserPreferences{}
+	}
+
+	var subgroups map[string]string
+	if len(customization.HiddenSubgroups) > 0 && prefs.Group != "" {
+		subgroups, err = s.lessonSubgroups(prefs.Group, start, end)
+		if err != nil {
+			logger.Logger.Warn().
+				Err(err).
+				Str("login", login).
+				Str("group", prefs.Group).
+				Msg("failed to resolve lesson subgroups for attendance")
+		}
+	}
+
+	return applyAttendanceCustomization(records, customization, prefs.HiddenSubjects, subgroups), nil
+}
+
+func (s *CustomizationService) lessonSubgroups(group, start, end string) (map[string]string, error) {
+	events, err := s.schedule.GetSchedule(group, "", "", "", start, end)
+	if err != nil {
+		return nil, err
+	}
+
+	subgroups := make(map[string]string)
+	for _, ev := range events {
+		for _, sg := range ev.SubGroup {
+			subgroups[sg.SClID] = sg.SGrID
+		}
+		if len(ev.SubGroup) == 1 {
+			subgroups[ev.ClID] = ev.SubGroup[0].SGrID
+		}
+	}
+
+	return subgroups, nil
+}
+
+type subjectOverrides map[string]domain.SubjectCustomization
+
+func newSubjectOverrides(subjects []domain.SubjectCustomization) subjectOverrides {
+	overrides := make(subjectOverrides, len(subjects))
+	for _, subject := range subjects {
+		overrides[strings.ToLower(strings.TrimSpace(subject.Title))] = subject
+	}
+	return overrides
+}
+
+func (o subjectOverrides) lookup(title string) (domain.SubjectCustomization, bool) {
+	subject, ok := o[strings.ToLower(strings.TrimSpace(title))]
+	return subject, ok
+}
+
+func applyScheduleCustomization(events []domain.ScheduleEvent, customization *domain.ScheduleCustomization) []domain.ScheduleEvent {
+	overrides := newSubjectOverrides(customization.Subjects)
+	hiddenSubgroups := lowerSet(customization.HiddenSubgroups)
+	hiddenEvents := lowerSet(customization.HiddenEvents)
+
+	result := make([]domain.ScheduleEvent, 0, len(events))
+	for _, ev := range events {
+		if hiddenEvents[strings.ToLower(ev.ClID)] || hiddenSubgroups[strings.ToLower(ev.SubGroupID)] {
+			continue
+		}
+
+		if len(ev.SubGroup) > 0 {
+			subgroups := make([]domain.SubGroup, 0, len(ev.SubGroup))
+			for _, sg := range ev.SubGroup {
+				if hiddenSubgroups[strings.ToLower(sg.SGrID)] || hiddenEvents[strings.ToLower(sg.SClID)] {
+					continue
+				}
+				if subject, ok := overrides.lookup(sg.STitle); ok && subject.Alias != "" {
+					sg.STitle = subject.Alias
+				}
+				subgroups = append(subgroups, sg)
+			}
+			if len(subgroups) == 0 {
+				continue
+			}
+			ev.SubGroup = subgroups
+		}
+
+		if subject, ok := overrides.lookup(ev.Title); ok {
+			if subject.Alias != "" {
+				ev.OriginalTitle = ev.Title
+				ev.Title = subject.Alias
+			}
+			if subject.Color != "" {
+				ev.Color = subject.Color
+			}
+		}
+
+		result = append(result, ev)
+	}
+
+	return result
+}
+
+func applyAttendanceCustomization(records []domain.AttendanceRecord, customization *domain.ScheduleCustomization, hiddenSubjects []string, subgroups map[string]string) []domain.AttendanceRecord {
+	overrides := newSubjectOverrides(customization.Subjects)
+	hiddenEvents := lowerSet(customization.HiddenEvents)
+	hiddenSubgroups := lowerSet(customization.HiddenSubgroups)
+	hiddenTitles := lowerSet(hiddenSubjects)
+
+	hidden := func(clID int, title string) bool {
+		id := strconv.Itoa(clID)
+		if hiddenEvents[id] || hiddenTitles[strings.ToLower(strings.TrimSpace(title))] {
+			return true
+		}
+		sgrID, ok := subgroups[id]
+		return ok && hiddenSubgroups[strings.ToLower(sgrID)]
+	}
+
+	result := make([]domain.AttendanceRecord, 0, len(records))
+	for _, r := range records {
+		if hidden(r.ClID, r.Title) || (r.SubClID != 0 && hidden(r.SubClID, r.Title)) {
+			continue
+		}
+
+		if len(r.SubGroup) > 0 {
+			subgroupRecords := make([]domain.AttendanceSubGroup, 0, len(r.SubGroup))
+			for _, sg := range r.SubGroup {
+				if hidden(sg.SClID, sg.STitle) {
+					continue
+				}
+				if subject, ok := overrides.lookup(sg.STitle); ok && subject.Alias != "" {
+					sg.STitle = subject.Alias
+				}
+				subgroupRecords = append(subgroupRecords, sg)
+			}
+			if len(subgroupRecords) == 0 {
+				continue
+			}
+			r.SubGroup = subgroupRecords
+		}
+
+		if subject, ok := overrides.lookup(r.Title); ok {
+			if subject.Alias != "" {
+				r.OriginalTitle = r.Title
+				r.Title = subject.Alias
+			}
+			if subject.Color != "" {
+				r.Color = subject.Color
+			}
+		}
+
+		result = append(result, r)
+	}
+
+	return result
+}
+
+func lowerSet(values []string) map[string]bool {
+	set := make(map[string]bool, len(values))
+	for _, v := range values {
+		set[strings.ToLower(strings.TrimSpace(v))] = true
+	}
+	return set
+}
@@ -0,0 +1,170 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/anton1ks96/college-app-core/internal/domain"
+	"github.com/anton1ks96/college-app-core/internal/notify"
+	"github.com/anton1ks96/college-app-core/internal/repository"
+	"github.com/anton1ks96/college-app-core/pkg/logger"
+)
+
+const defaultPollInterval = 15 * time.Minute
+
+type GradePoller struct {
+	performance *PerformanceService
+	calendar    *CalendarService
+	repo        repository.NotificationRepository
+	sink        notify.Sink
+	interval    time.Duration
+	workers     int
+}
+
+func NewGradePoller(performance *PerformanceService, calendar *CalendarService, repo repository.NotificationRepository, sink notify.Sink, interval time.Duration, workers int) *GradePoller {
+	if interval <= 0 {
+		interval = defaultPollInterval
+	}
+	return &GradePoller{
+		performance: performance,
+		calendar:    calendar,
+		repo:        repo,
+		sink:        sink,
+		interval:    interval,
+		workers:     workers,
+	}
+}
+
+func (p *GradePoller) Run(ctx context.Context) {
+	ticker := time.NewTicker(p.interval)
+	defer ticker.Stop()
+
+	for {
+		p.poll(ctx)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (p *GradePoller) poll(ctx context.Context) {
+	logins, err := p.repo.Subscribers()
+	if err != nil {
+		logger.Error(fmt.Errorf("failed to list grade subscribers: %w", err))
+		return
+	}
+
+	runBounded(len(logins), p.workers, func(i int) {
+		if ctx.Err() != nil {
+			return
+		}
+		if err := p.pollUser(ctx, logins[i]); err != nil {
+			logger.Logger.Error().
+				Err(err).
+				Str("login", logins[i]).
+				Msg("failed to poll grades")
+		}
+	})
+}
+
+func (p *GradePoller) pollUser(ctx context.Context, login string) error {
+	now := time.Now()
+	yearStart, err := p.calendar.AcademicYearStart(now)
+	if err != nil {
+		return err
+	}
+	start := yearStart.Format(dateLayout)
+	end := now.Format(dateLayout)
+
+	results, err := p.performance.fetchAllScores(login, start, end)
+	if err != nil {
+		return err
+	}
+
+	previous, hasPrevious, err := p.repo.SeenScores(login)
+	if err != nil {
+		return fmt.Errorf("failed to load seen scores: %w", err)
+	}
+
+	seen := make(map[string]string)
+	notifications := make([]domain.GradeNotification, 0)
+	keys := make([]string, 0)
+
+	for _, res := range results {
+		if res.err != nil {
+			prefix := res.subject.SuID + "|"
+			for key, value := range previous {
+				if strings.HasPrefix(key, prefix) {
+					seen[key] = value
+				}
+			}
+			continue
+		}
+
+		for _, entry := range flattenGradebook(res.scores) {
+			key := strings.Join([]string{res.subject.SuID, entry.Subject, entry.WorkType, entry.DateF, entry.DateP, entry.Description}, "|")
+			value := entry.Score + "|" + strconv.Itoa(entry.MaxScore)
+			seen[key] = value
+
+			if !hasPrevious {
+				continue
+			}
+
+			prev, existed := previous[key]
+			if existed && prev == value {
+				continue
+			}
+
+			n := domain.GradeNotification{
+				Kind:        domain.GradeNotificationNew,
+				Login:       login,
+				SuID:        res.subject.SuID,
+				Subject:     entry.Subject,
+				WorkType:    entry.WorkType,
+				DateF:       entry.DateF,
+				DateP:       entry.DateP,
+				Description: entry.Description,
+				Score:       entry.Score,
+				MaxScore:    entry.MaxScore,
+				DetectedAt:  now,
+			}
+			if existed {
+				n.Kind = domain.GradeNotificationChanged
+				n.PreviousScore = seenScore(prev)
+			}
+			notifications = append(notifications, n)
+			keys = append(keys, key)
+		}
+	}
+
+	for i, n := range notifications {
+		if err := p.sink.Send(ctx, n); err != nil {
+			for _, key := range keys[i:] {
+				if prev, ok := previous[key]; ok {
+					seen[key] = prev
+				} else {
+					delete(seen, key)
+				}
+			}
+			if saveErr := p.repo.SaveSeenScores(login, seen); saveErr != nil {
+				return fmt.Errorf("failed to save seen scores: %w", saveErr)
+			}
+			return fmt.Errorf("failed to send notification: %w", err)
+		}
+	}
+
+	return p.repo.SaveSeenScores(login, seen)
+}
+
+func seenScore(value string) string {
+	if i := strings.LastIndex(value, "|"); i >= 0 {
+		return value[:i]
+	}
+	return value
+}
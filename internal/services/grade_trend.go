@@ -0,0 +1,93 @@
+package services
+
+import (
+	"github.com/anton1ks96/college-app-core/internal/domain"
+)
+
+const (
+	defaultTrendWindow  = 3
+	defaultSlopeEntries = 5
+)
+
+func (s *PerformanceService) GetTrend(login, suID, start, end string, window, slopeEntries int) (*domain.GradeTrend, error) {
+	if window <= 0 {
+		window = s.trendWindow
+	}
+	if window <= 0 {
+		window = defaultTrendWindow
+	}
+	if slopeEntries <= 0 {
+		slopeEntries = s.slopeEntries
+	}
+	if slopeEntries <= 0 {
+		slopeEntries = defaultSlopeEntries
+	}
+
+	scores, err := s.GetScore(login, suID, start, end)
+	if err != nil {
+		return nil, err
+	}
+
+	trend := &domain.GradeTrend{
+		SuID:         suID,
+		PeriodStart:  start,
+		PeriodEnd:    end,
+		Scale:        s.grading.scale,
+		Window:       window,
+		SlopeEntries: slopeEntries,
+		Points:       make([]domain.TrendPoint, 0),
+	}
+
+	values := make([]float64, 0)
+	for _, entry := range flattenGradebook(scores) {
+		if entry.Normalized.Kind != domain.ScoreKindNumeric {
+			continue
+		}
+		value := s.grading.toScale(entry.Normalized.Ratio)
+		values = append(values, value)
+		trend.Points = append(trend.Points, domain.TrendPoint{
+			Date:        entry.DateP,
+			WorkType:    entry.WorkType,
+			Description: entry.Description,
+			Score:       entry.Score,
+			MaxScore:    entry.Normalized.MaxScore,
+			Value:       roundScore(value),
+		})
+	}
+
+	if len(values) == 0 {
+		return trend, nil
+	}
+
+	averages := movingAverages(values, window)
+	for i, avg := range averages {
+		trend.Points[i].MovingAverage = roundScore(avg)
+	}
+
+	tail := values
+	if len(tail) > slopeEntries {
+		tail = tail[len(tail)-slopeEntries:]
+	}
+	slope := linearSlope(tail)
+
+	sum := 0.0
+	for _, v := range values {
+		sum += v
+	}
+	average := sum / float64(len(values))
+
+	projected := sum
+	base := averages[len(averages)-1]
+	for i := 1; i <= window; i++ {
+		projected += min(max(base+slope*float64(i), 0), s.grading.scale)
+	}
+	predicted := projected / float64(len(values)+window)
+
+	trend.Average = roundScore(average)
+	trend.Slope = roundScore(slope)
+	trend.CurrentMark = s.grading.mark(roundScore(average))
+	trend.PredictedValue = roundScore(predicted)
+	trend.PredictedMark = s.grading.mark(trend.PredictedValue)
+
+	return trend, nil
+}
@@ -0,0 +1,46 @@
+package services
+
+import (
+	"github.com/anton1ks96/college-app-core/internal/domain"
+)
+
+func (s *PerformanceService) WhatIf(login, suID, start, end string, hypothetical []domain.WhatIfScore) (*domain.WhatIfResult, error) {
+	scores, err := s.GetScore(login, suID, start, end)
+	if err != nil {
+		return nil, err
+	}
+
+	var real scoreAverager
+	for _, entry := range flattenGradebook(scores) {
+		real.add(entry.Normalized)
+	}
+
+	combined := real
+	for _, h := range hypothetical {
+		combined.add(domain.NormalizedScore{
+			Kind:     domain.ScoreKindNumeric,
+			Value:    h.Value,
+			MaxScore: h.MaxScore,
+			Ratio:    h.Value / float64(h.MaxScore),
+		})
+	}
+
+	result := &domain.WhatIfResult{
+		SuID:                   suID,
+		Scale:                  s.grading.scale,
+		RealScores:             real.count,
+		HypotheticalScores:     len(hypothetical),
+		CurrentAverage:         roundScore(s.grading.toScale(real.average())),
+		CurrentWeightedAverage: roundScore(s.grading.toScale(real.weightedAverage())),
+		Average:                roundScore(s.grading.toScale(combined.average())),
+		WeightedAverage:        roundScore(s.grading.toScale(combined.weightedAverage())),
+	}
+	if real.count > 0 {
+		result.CurrentMark = s.grading.mark(result.CurrentAverage)
+	}
+	if combined.count > 0 {
+		result.Mark = s.grading.mark(result.Average)
+	}
+
+	return result, nil
+}
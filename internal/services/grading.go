@@ -0,0 +1,87 @@
+package services
+
+import (
+	"math"
+	"sort"
+
+	"github.com/anton1ks96/college-app-core/internal/config"
+)
+
+const defaultGradingScale = 5
+
+type gradingScheme struct {
+	scale float64
+	marks []config.GradeMark
+}
+
+func newGradingScheme(cfg config.Grading) gradingScheme {
+	scheme := gradingScheme{
+		scale: cfg.Scale,
+		marks: make([]config.GradeMark, len(cfg.Marks)),
+	}
+	if scheme.scale <= 0 {
+		scheme.scale = defaultGradingScale
+	}
+
+	copy(scheme.marks, cfg.Marks)
+	sort.Slice(scheme.marks, func(i, j int) bool {
+		return scheme.marks[i].Min > scheme.marks[j].Min
+	})
+
+	return scheme
+}
+
+func (g gradingScheme) toScale(ratio float64) float64 {
+	return ratio * g.scale
+}
+
+func (g gradingScheme) mark(value float64) int {
+	for _, m := range g.marks {
+		if value >= m.Min-1e-9 {
+			return m.Mark
+		}
+	}
+	return 0
+}
+
+func movingAverages(values []float64, window int) []float64 {
+	result := make([]float64, len(values))
+	sum := 0.0
+
+	for i, v := range values {
+		sum += v
+		if i >= window {
+			sum -= values[i-window]
+		}
+		n := min(i+1, window)
+		result[i] = sum / float64(n)
+	}
+
+	return result
+}
+
+func linearSlope(values []float64) float64 {
+	n := float64(len(values))
+	if n < 2 {
+		return 0
+	}
+
+	var sumX, sumY, sumXY, sumXX float64
+	for i, y := range values {
+		x := float64(i)
+		sumX += x
+		sumY += y
+		sumXY += x * y
+		sumXX += x * x
+	}
+
+	denom := n*sumXX - sumX*sumX
+	if denom == 0 {
+		return 0
+	}
+	return (n*sumXY - sumX*sumY) / denom
+}
+
+func roundScore(v float64) float64 {
+	return math.Round(v*100) / 100
+}
@@ -0,0 +1,88 @@
+package services
+
+import (
+	"testing"
+
+	"github.com/anton1ks96/college-app-core/internal/config"
+)
+
+func TestGradingSchemeMark(t *testing.T) {
+	scheme := newGradingScheme(config.Grading{
+		Marks: []config.GradeMark{
+			{Mark: 3, Min: 2.5},
+			{Mark: 5, Min: 4.5},
+			{Mark: 2, Min: 0},
+			{Mark: 4, Min: 3.5},
+		},
+	})
+
+	tests := []struct {
+		ratio float64
+		scale float64
+		mark  int
+	}{
+		{ratio: 1, scale: 5, mark: 5},
+		{ratio: 0.9, scale: 4.5, mark: 5},
+		{ratio: 0.89, scale: 4.45, mark: 4},
+		{ratio: 0.7, scale: 3.5, mark: 4},
+		{ratio: 0.5, scale: 2.5, mark: 3},
+		{ratio: 0.1, scale: 0.5, mark: 2},
+	}
+
+	for _, tt := range tests {
+		value := scheme.toScale(tt.ratio)
+		if roundScore(value) != tt.scale {
+			t.Errorf("toScale(%v) = %v, want %v", tt.ratio, value, tt.scale)
+		}
+		if got := scheme.mark(value); got != tt.mark {
+			t.Errorf("mark(%v) = %d, want %d", value, got, tt.mark)
+		}
+	}
+}
+
+func TestGradingSchemeDefaults(t *testing.T) {
+	scheme := newGradingScheme(config.Grading{})
+	if got := scheme.toScale(0.5); got != 2.5 {
+		t.Errorf("toScale(0.5) = %v, want 2.5 on the default scale", got)
+	}
+	if got := scheme.mark(5); got != 0 {
+		t.Errorf("mark without configured marks = %d, want 0", got)
+	}
+
+	scheme = newGradingScheme(config.Grading{Scale: 100, Marks: []config.GradeMark{{Mark: 1, Min: 50}}})
+	if got := scheme.toScale(0.5); got != 50 {
+		t.Errorf("toScale(0.5) = %v, want 50", got)
+	}
+	if got := scheme.mark(49.99); got != 0 {
+		t.Errorf("mark(49.99) = %d, want 0", got)
+	}
+}
+
+func TestMovingAverages(t *testing.T) {
+	got := movingAverages([]float64{2, 4, 6, 8}, 2)
+	want := []float64{2, 3, 5, 7}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("movingAverages[%d] = %v, want %v", i, got[i], want[i])
+		}
+	}
+}
+
+func TestLinearSlope(t *testing.T) {
+	tests := []struct {
+		values []float64
+		want   float64
+	}{
+		{values: nil, want: 0},
+		{values: []float64{4}, want: 0},
+		{values: []float64{1, 2, 3, 4}, want: 1},
+		{values: []float64{5, 5, 5}, want: 0},
+		{values: []float64{5, 4, 3}, want: -1},
+	}
+
+	for _, tt := range tests {
+		if got := linearSlope(tt.values); roundScore(got) != tt.want {
+			t.Errorf("linearSlope(%v) = %v, want %v", tt.values, got, tt.want)
+		}
+	}
+}
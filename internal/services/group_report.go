@@ -0,0 +1,168 @@
+package services
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/anton1ks96/college-app-core/internal/config"
+	"github.com/anton1ks96/college-app-core/internal/domain"
+	"github.com/anton1ks96/college-app-core/internal/repository"
+)
+
+type GroupReportService struct {
+	roster       repository.RosterRepository
+	attendance   *AttendanceService
+	curatorRoles map[string]bool
+	workers      int
+	rateLimit    int
+}
+
+func NewGroupReportService(roster repository.RosterRepository, attendance *AttendanceService, cfg config.Roster) *GroupReportService {
+	return &GroupReportService{
+		roster:       roster,
+		attendance:   attendance,
+		curatorRoles: lowerSet(cfg.CuratorRoles),
+		workers:      cfg.Workers,
+		rateLimit:    cfg.RateLimit,
+	}
+}
+
+func (s *GroupReportService) CanAccess(group, login, role string) (bool, error) {
+	if !s.curatorRoles[strings.ToLower(role)] {
+		return true, nil
+	}
+
+	curators, err := s.roster.FetchCurators(group)
+	if err != nil {
+		return false, fmt.Errorf("failed to fetch curators: %w", err)
+	}
+
+	for _, curator := range curators {
+		if strings.EqualFold(curator, login) {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
+
+func (s *GroupReportService) GetGroupAttendance(group, start, end string) (*domain.GroupAttendanceReport, error) {
+	students, err := s.roster.FetchRoster(group)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch roster: %w", err)
+	}
+
+	records := make([][]domain.AttendanceRecord, len(students))
+	errs := make([]error, len(students))
+
+	limiter := newRateLimiter(s.rateLimit)
+	defer limiter.Stop()
+
+	runBounded(len(students), s.workers, func(i int) {
+		limiter.Wait()
+		records[i], errs[i] = s.attendance.GetAttendance(students[i].Login, start, end)
+	})
+
+	daySet := make(map[string]bool)
+	for _, studentRecords := range records {
+		for _, r := range studentRecords {
+			daySet[r.Day] = true
+		}
+	}
+	days := make([]string, 0, len(daySet))
+	for day := range daySet {
+		days = append(days, day)
+	}
+	sort.Strings(days)
+
+	report := &domain.GroupAttendanceReport{
+		Group:       group,
+		PeriodStart: start,
+		PeriodEnd:   end,
+		Days:        days,
+		Students:    make([]domain.GroupAttendanceRow, 0, len(students)),
+	}
+
+	for i, student := range students {
+		row := domain.GroupAttendanceRow{
+			Login: student.Login,
+			Name:  student.Name,
+		}
+		if errs[i] != nil {
+			row.Error = errs[i].Error()
+		} else {
+			row.Cells = s.buildGroupCells(days, records[i])
+			for _, cell := range row.Cells {
+				row.Attended += cell.Attended
+				row.Missed += cell.Missed
+			}
+			if marked := row.Attended + row.Missed; marked > 0 {
+				row.AttendanceRate = float64(row.Attended) / float64(marked)
+			}
+		}
+		report.Students = append(report.Students, row)
+	}
+
+	sort.SliceStable(report.Students, func(i, j int) bool {
+		return report.Students[i].Name < report.Students[j].Name
+	})
+
+	return report, nil
+}
+
+func (s *GroupReportService) buildGroupCells(days []string, records []domain.AttendanceRecord) []domain.GroupAttendanceCell {
+	byDay := make(map[string]map[domain.AttendanceStatus]int)
+	cells := make(map[string]*domain.GroupAttendanceCell)
+
+	for _, r := range records {
+		cell, ok := cells[r.Day]
+		if !ok {
+			cell = &domain.GroupAttendanceCell{}
+			cells[r.Day] = cell
+			byDay[r.Day] = make(map[domain.AttendanceStatus]int)
+		}
+		byDay[r.Day][r.StatusName]++
+
+		switch {
+		case r.StatusName.Attended():
+			cell.Attended++
+		case s.attendance.countsAsMissed(r.StatusName):
+			cell.Missed++
+		}
+	}
+
+	result := make([]domain.GroupAttendanceCell, len(days))
+	for i, day := range days {
+		if cell, ok := cells[day]; ok {
+			cell.Status = dominantStatus(byDay[day])
+			result[i] = *cell
+		}
+	}
+
+	return result
+}
+
+func GroupAttendanceRows(report *domain.GroupAttendanceReport) [][]any {
+	header := []any{"login", "name"}
+	for _, day := range report.Days {
+		header = append(header, day)
+	}
+	header = append(header, "attended", "missed", "attendance_rate", "error")
+
+	rows := [][]any{header}
+	for _, student := range report.Students {
+		row := []any{student.Login, student.Name}
+		for i := range report.Days {
+			if i < len(student.Cells) {
+				row = append(row, string(student.Cells[i].Status))
+			} else {
+				row = append(row, "")
+			}
+		}
+		row = append(row, student.Attended, student.Missed, student.AttendanceRate, student.Error)
+		rows = append(rows, row)
+	}
+
+	return rows
+}
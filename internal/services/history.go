@@ -0,0 +1,52 @@
+package services
+
+import (
+	"github.com/anton1ks96/college-app-core/internal/domain"
+	"github.com/anton1ks96/college-app-core/internal/storage"
+)
+
+type HistoryService struct {
+	store storage.Storage
+}
+
+func NewHistoryService(store storage.Storage) *HistoryService {
+	return &HistoryService{
+		store: store,
+	}
+}
+
+func (s *HistoryService) GetAttendanceHistory(login, start, end string) (*domain.AttendanceHistoryResponse, error) {
+	from, to, err := parseRange(start, end)
+	if err != nil {
+		return nil, err
+	}
+
+	changes, err := s.store.AttendanceHistory(login, from, to.AddDate(0, 0, 1))
+	if err != nil {
+		return nil, err
+	}
+
+	return &domain.AttendanceHistoryResponse{
+		PeriodStart: start,
+		PeriodEnd:   end,
+		Changes:     changes,
+	}, nil
+}
+
+func (s *HistoryService) GetScoreHistory(login, start, end string) (*domain.ScoreHistoryResponse, error) {
+	from, to, err := parseRange(start, end)
+	if err != nil {
+		return nil, err
+	}
+
+	changes, err := s.store.ScoreHistory(login, from, to.AddDate(0, 0, 1))
+	if err != nil {
+		return nil, err
+	}
+
+	return &domain.ScoreHistoryResponse{
+		PeriodStart: start,
+		PeriodEnd:   end,
+		Changes:     changes,
+	}, nil
+}
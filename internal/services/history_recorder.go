@@ -0,0 +1,128 @@
+package services
+
+import (
+	"context"
+	"time"
+
+	"github.com/anton1ks96/college-app-core/internal/domain"
+	"github.com/anton1ks96/college-app-core/internal/storage"
+	"github.com/anton1ks96/college-app-core/pkg/logger"
+)
+
+const defaultHistoryQueueSize = 256
+
+type historyJob struct {
+	name  string
+	login string
+	write func(store storage.Storage) error
+}
+
+type HistoryRecorder struct {
+	store storage.Storage
+	jobs  chan historyJob
+	done  chan struct{}
+}
+
+func NewHistoryRecorder(store storage.Storage, queueSize int) *HistoryRecorder {
+	if queueSize <= 0 {
+		queueSize = defaultHistoryQueueSize
+	}
+	return &HistoryRecorder{
+		store: store,
+		jobs:  make(chan historyJob, queueSize),
+		done:  make(chan struct{}),
+	}
+}
+
+func (r *HistoryRecorder) Run(ctx context.Context) {
+	defer close(r.done)
+
+	for {
+		select {
+		case job := <-r.jobs:
+			r.write(job)
+		case <-ctx.Done():
+			for {
+				select {
+				case job := <-r.jobs:
+					r.write(job)
+				default:
+					return
+				}
+			}
+		}
+	}
+}
+
+func (r *HistoryRecorder) Wait() {
+	<-r.done
+}
+
+func (r *HistoryRecorder) RecordAttendance(login string, records []domain.AttendanceRecord, observedAt time.Time) {
+	records = cloneAttendanceRecords(records)
+	r.enqueue(historyJob{
+		name:  "attendance history",
+		login: login,
+		write: func(store storage.Storage) error {
+			return store.RecordAttendance(login, records, observedAt)
+		},
+	})
+}
+
+func (r *HistoryRecorder) RecordScores(login, suID string, entries []domain.GradebookEntry, observedAt time.Time) {
+	r.enqueue(historyJob{
+		name:  "score history",
+		login: login,
+		write: func(store storage.Storage) error {
+			return store.RecordScores(login, suID, entries, observedAt)
+		},
+	})
+}
+
+func (r *HistoryRecorder) SaveScheduleSnapshot(snapshot domain.ScheduleSnapshot) {
+	snapshot.Events = cloneScheduleEvents(snapshot.Events)
+	r.enqueue(historyJob{
+		name: "schedule snapshot",
+		write: func(store storage.Storage) error {
+			return store.SaveScheduleSnapshot(snapshot)
+		},
+	})
+}
+
+func cloneScheduleEvents(events []domain.ScheduleEvent) []domain.ScheduleEvent {
+	cloned := make([]domain.ScheduleEvent, len(events))
+	for i, ev := range events {
+		ev.SubGroup = append([]domain.SubGroup(nil), ev.SubGroup...)
+		ev.Notes = append([]domain.LessonNote(nil), ev.Notes...)
+		cloned[i] = ev
+	}
+	return cloned
+}
+
+func cloneAttendanceRecords(records []domain.AttendanceRecord) []domain.AttendanceRecord {
+	cloned := make([]domain.AttendanceRecord, len(records))
+	for i, r := range records {
+		r.SubGroup = append([]domain.AttendanceSubGroup(nil), r.SubGroup...)
+		cloned[i] = r
+	}
+	return cloned
+}
+
+func (r *HistoryRecorder) enqueue(job historyJob) {
+	select {
+	case r.jobs <- job:
+	default:
+		logger.Logger.Warn().
+			Str("login", job.login).
+			Msg("history queue is full, dropping " + job.name)
+	}
+}
+
+func (r *HistoryRecorder) write(job historyJob) {
+	if err := job.write(r.store); err != nil {
+		logger.Logger.Error().
+			Err(err).
+			Str("login", job.login).
+			Msg("failed to record " + job.name)
+	}
+}
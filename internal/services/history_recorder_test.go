@@ -0,0 +1,113 @@
+package services
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/anton1ks96/college-app-core/internal/domain"
+	"github.com/anton1ks96/college-app-core/internal/storage"
+)
+
+type snapshotStore struct {
+	storage.Storage
+
+	mu        sync.Mutex
+	snapshots []domain.ScheduleSnapshot
+	records   [][]domain.AttendanceRecord
+}
+
+func (s *snapshotStore) SaveScheduleSnapshot(snapshot domain.ScheduleSnapshot) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.snapshots = append(s.snapshots, snapshot)
+	return nil
+}
+
+func (s *snapshotStore) RecordAttendance(_ string, records []domain.AttendanceRecord, _ time.Time) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.records = append(s.records, records)
+	return nil
+}
+
+func TestHistoryRecorderCopiesScheduleSnapshot(t *testing.T) {
+	store := &snapshotStore{}
+	recorder := NewHistoryRecorder(store, 4)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	go recorder.Run(ctx)
+
+	events := []domain.ScheduleEvent{{
+		ClID:  "1",
+		Day:   "2026-09-07",
+		Start: "09:00",
+		End:   "10:30",
+		SubGroup: []domain.SubGroup{
+			{SClID: "11", SGrID: "A2.01", STitle: "English A2"},
+			{SClID: "12", SGrID: "B1.02", STitle: "English B1"},
+		},
+	}}
+	recorder.SaveScheduleSnapshot(domain.ScheduleSnapshot{Group: "G", Start: "2026-09-07", End: "2026-09-07", Events: events})
+
+	filterEventsForSelection(events, "Подгр1", "B1.02", "")
+
+	cancel()
+	recorder.Wait()
+
+	if len(store.snapshots) != 1 {
+		t.Fatalf("got %d snapshots, want 1", len(store.snapshots))
+	}
+	saved := store.snapshots[0].Events[0].SubGroup
+	if len(saved) != 2 || saved[0].SGrID != "A2.01" || saved[1].SGrID != "B1.02" {
+		t.Fatalf("snapshot subgroups were modified by the caller: %+v", saved)
+	}
+}
+
+func TestHistoryRecorderCopiesAttendanceRecords(t *testing.T) {
+	store := &snapshotStore{}
+	recorder := NewHistoryRecorder(store, 4)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	go recorder.Run(ctx)
+
+	records := []domain.AttendanceRecord{{
+		ClID:     1,
+		Title:    "Math",
+		SubGroup: []domain.AttendanceSubGroup{{SClID: 11, STitle: "Math 1"}},
+	}}
+	recorder.RecordAttendance("student", records, time.Now())
+
+	records[0].Title = "changed"
+	records[0].SubGroup[0].STitle = "changed"
+
+	cancel()
+	recorder.Wait()
+
+	if len(store.records) != 1 {
+		t.Fatalf("got %d attendance writes, want 1", len(store.records))
+	}
+	saved := store.records[0][0]
+	if saved.Title != "Math" || saved.SubGroup[0].STitle != "Math 1" {
+		t.Fatalf("attendance records were modified by the caller: %+v", saved)
+	}
+}
+
+func TestHistoryRecorderDropsWhenQueueIsFull(t *testing.T) {
+	store := &snapshotStore{}
+	recorder := NewHistoryRecorder(store, 1)
+
+	recorder.SaveScheduleSnapshot(domain.ScheduleSnapshot{Group: "A"})
+	recorder.SaveScheduleSnapshot(domain.ScheduleSnapshot{Group: "B"})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	recorder.Run(ctx)
+
+	if len(store.snapshots) != 1 || store.snapshots[0].Group != "A" {
+		t.Fatalf("got snapshots %+v, want only group A", store.snapshots)
+	}
+}
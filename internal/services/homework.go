@@ -0,0 +1,235 @@
+package services
+
+import (
+	"html"
+	"net/url"
+	"path"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/anton1ks96/college-app-core/internal/config"
+	"github.com/anton1ks96/college-app-core/internal/domain"
+)
+
+var (
+	htmlBreakRe = regexp.MustCompile(`(?i)<\s*(br|/p|/div|/li)\s*/?>`)
+	htmlTagRe   = regexp.MustCompile(`<[^>]*>`)
+	blankLineRe = regexp.MustCompile(`\n\s*\n+`)
+)
+
+var (
+	attachmentNameKeys = []string{"name", "title", "filename", "file_name"}
+	attachmentURLKeys  = []string{"url", "href", "link", "path", "file"}
+)
+
+type HomeworkService struct {
+	schedule       *ScheduleService
+	classDetails   *ClassDetailsService
+	baseURL        *url.URL
+	workers        int
+	textKeys       map[string]bool
+	attachmentKeys map[string]bool
+}
+
+func NewHomeworkService(schedule *ScheduleService, classDetails *ClassDetailsService, portalURL string, cfg config.Homework) *HomeworkService {
+	base, _ := url.Parse(portalURL)
+
+	return &HomeworkService{
+		schedule:       schedule,
+		classDetails:   classDetails,
+		baseURL:        base,
+		workers:        cfg.Workers,
+		textKeys:       lowerSet(cfg.TextKeys),
+		attachmentKeys: lowerSet(cfg.AttachmentKeys),
+	}
+}
+
+func (s *HomeworkService) GetHomework(sel domain.ScheduleSelection, start, end string) (*domain.HomeworkResponse, error) {
+	events, err := s.schedule.GetSchedule(sel.Group, sel.Subgroup, sel.EnglishGroup, sel.ProfileSubgroup, start, end)
+	if err != nil {
+		return nil, err
+	}
+
+	items := make([]domain.HomeworkItem, len(events))
+	runBounded(len(events), s.workers, func(i int) {
+		ev := events[i]
+		items[i] = domain.HomeworkItem{
+			ClID:        ev.ClID,
+			Day:         ev.Day,
+			Start:       ev.Start,
+			End:         ev.End,
+			Title:       ev.Title,
+			Period:      ev.Period,
+			Attachments: make([]domain.HomeworkAttachment, 0),
+		}
+
+		details, err := s.classDetails.GetClassDetails(ev.ClID)
+		if err != nil {
+			items[i].Error = err.Error()
+			return
+		}
+
+		items[i].Text, items[i].Attachments = s.extract(details)
+	})
+
+	resp := &domain.HomeworkResponse{
+		PeriodStart: start,
+		PeriodEnd:   end,
+		Items:       make([]domain.HomeworkItem, 0),
+	}
+	for _, item := range items {
+		switch {
+		case item.Error != "":
+			resp.Failed++
+		case item.Text == "" && len(item.Attachments) == 0:
+			continue
+		}
+		resp.Items = append(resp.Items, item)
+	}
+
+	sort.SliceStable(resp.Items, func(i, j int) bool {
+		if resp.Items[i].Day != resp.Items[j].Day {
+			return resp.Items[i].Day < resp.Items[j].Day
+		}
+		return resp.Items[i].Start < resp.Items[j].Start
+	})
+
+	return resp, nil
+}
+
+func (s *HomeworkService) extract(details map[string]any) (string, []domain.HomeworkAttachment) {
+	texts := make([]string, 0)
+	attachments := make([]domain.HomeworkAttachment, 0)
+	seen := make(map[string]bool)
+
+	var walk func(value any)
+	walk = func(value any) {
+		switch v := value.(type) {
+		case map[string]any:
+			for _, key := range sortedMapKeys(v) {
+				lk := strings.ToLower(key)
+				switch {
+				case s.textKeys[lk]:
+					for _, text := range collectStrings(v[key]) {
+						if text = cleanHomeworkText(text); text != "" && !seen[text] {
+							seen[text] = true
+							texts = append(texts, text)
+						}
+					}
+				case s.attachmentKeys[lk]:
+					for _, a := range s.collectAttachments(v[key]) {
+						if !seen[a.URL] {
+							seen[a.URL] = true
+							attachments = append(attachments, a)
+						}
+					}
+				default:
+					walk(v[key])
+				}
+			}
+		case []any:
+			for _, item := range v {
+				walk(item)
+			}
+		}
+	}
+	walk(details)
+
+	return strings.Join(texts, "\n\n"), attachments
+}
+
+func (s *HomeworkService) collectAttachments(value any) []domain.HomeworkAttachment {
+	result := make([]domain.HomeworkAttachment, 0)
+
+	switch v := value.(type) {
+	case string:
+		if link := s.resolveURL(v); link != "" {
+			result = append(result, domain.HomeworkAttachment{Name: path.Base(link), URL: link})
+		}
+	case []any:
+		for _, item := range v {
+			result = append(result, s.collectAttachments(item)...)
+		}
+	case map[string]any:
+		link := s.resolveURL(firstString(v, attachmentURLKeys))
+		if link == "" {
+			return result
+		}
+		name := firstString(v, attachmentNameKeys)
+		if name == "" {
+			name = path.Base(link)
+		}
+		result = append(result, domain.HomeworkAttachment{Name: name, URL: link})
+	}
+
+	return result
+}
+
+func (s *HomeworkService) resolveURL(raw string) string {
+	raw = strings.TrimSpace(raw)
+	if raw == "" || strings.ContainsAny(raw, " \t\n") {
+		return ""
+	}
+
+	ref, err := url.Parse(raw)
+	if err != nil {
+		return ""
+	}
+	if ref.IsAbs() || s.baseURL == nil {
+		return ref.String()
+	}
+	return s.baseURL.ResolveReference(ref).String()
+}
+
+func collectStrings(value any) []string {
+	switch v := value.(type) {
+	case string:
+		return []string{v}
+	case []any:
+		result := make([]string, 0, len(v))
+		for _, item := range v {
+			result = append(result, collectStrings(item)...)
+		}
+		return result
+	case map[string]any:
+		result := make([]string, 0, len(v))
+		for _, key := range sortedMapKeys(v) {
+			result = append(result, collectStrings(v[key])...)
+		}
+		return result
+	}
+	return nil
+}
+
+func cleanHomeworkText(text string) string {
+	text = htmlBreakRe.ReplaceAllString(text, "\n")
+	text = htmlTagRe.ReplaceAllString(text, "")
+	text = html.UnescapeString(text)
+	text = strings.ReplaceAll(text, "\r\n", "\n")
+	text = blankLineRe.ReplaceAllString(text, "\n\n")
+	return strings.TrimSpace(text)
+}
+
+func firstString(m map[string]any, keys []string) string {
+	for _, key := range keys {
+		for k, v := range m {
+			if !strings.EqualFold(k, key) {
+				continue
+			}
+			if s, ok := v.(string); ok && strings.TrimSpace(s) != "" {
+				return strings.TrimSpace(s)
+			}
+		}
+	}
+	return ""
+}
+
+func sortedMapKeys(m map[string]any) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
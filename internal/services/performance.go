@@ -2,18 +2,31 @@ package services
 
 import (
 	"fmt"
+	"sort"
+	"time"
 
+	"github.com/anton1ks96/college-app-core/internal/config"
 	"github.com/anton1ks96/college-app-core/internal/domain"
 	"github.com/anton1ks96/college-app-core/internal/repository"
 )
 
 type PerformanceService struct {
-	portal *repository.PortalRepository
+	portal       *repository.PortalRepository
+	history      *HistoryRecorder
+	workers      int
+	grading      gradingScheme
+	trendWindow  int
+	slopeEntries int
 }
 
-func NewPerformanceService(portal *repository.PortalRepository) *PerformanceService {
+func NewPerformanceService(portal *repository.PortalRepository, history *HistoryRecorder, workers int, grading config.Grading) *PerformanceService {
 	return &PerformanceService{
-		portal: portal,
+		portal:       portal,
+		history:      history,
+		workers:      workers,
+		grading:      newGradingScheme(grading),
+		trendWindow:  grading.TrendWindow,
+		slopeEntries: grading.SlopeEntries,
 	}
 }
 
@@ -40,3 +53,187 @@ func (s *PerformanceService) GetScore(login, suID, start, end string) (map[strin
 
 	return scores, nil
 }
+
+type subjectScores struct {
+	subject domain.PerformanceSubject
+	scores  map[string]map[string][]domain.PerformanceScore
+	err     error
+}
+
+func (s *PerformanceService) fetchAllScores(login, start, end string) ([]subjectScores, error) {
+	subjects, err := s.GetSubjects(login)
+	if err != nil {
+		return nil, err
+	}
+
+	results := make([]subjectScores, len(subjects))
+	runBounded(len(subjects), s.workers, func(i int) {
+		results[i].subject = subjects[i]
+		results[i].scores, results[i].err = s.GetScore(login, subjects[i].SuID, start, end)
+	})
+
+	now := time.Now()
+	for _, res := range results {
+		if res.err != nil {
+			continue
+		}
+		s.history.RecordScores(login, res.subject.SuID, flattenGradebook(res.scores), now)
+	}
+
+	return results, nil
+}
+
+func (s *PerformanceService) GetGradebook(login, start, end string) (*domain.GradebookResponse, error) {
+	results, err := s.fetchAllScores(login, start, end)
+	if err != nil {
+		return nil, err
+	}
+
+	return buildGradebook(results, start, end), nil
+}
+
+func (s *PerformanceService) GetSummary(login, start, end string) (*domain.PerformanceSummaryResponse, error) {
+	results, err := s.fetchAllScores(login, start, end)
+	if err != nil {
+		return nil, err
+	}
+
+	return buildSummary(results, start, end), nil
+}
+
+func (s *PerformanceService) GetStudentPerformance(login, start, end string) (*domain.GradebookResponse, *domain.PerformanceSummaryResponse, error) {
+	results, err := s.fetchAllScores(login, start, end)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return buildGradebook(results, start, end), buildSummary(results, start, end), nil
+}
+
+func buildGradebook(results []subjectScores, start, end string) *domain.GradebookResponse {
+	resp := &domain.GradebookResponse{
+		PeriodStart: start,
+		PeriodEnd:   end,
+		Subjects:    make([]domain.GradebookSubject, 0, len(results)),
+	}
+
+	for _, res := range results {
+		subject := domain.GradebookSubject{
+			SuID:    res.subject.SuID,
+			Title:   res.subject.Title,
+			Entries: make([]domain.GradebookEntry, 0),
+		}
+		if res.err != nil {
+			subject.Error = res.err.Error()
+			resp.Failed++
+		} else {
+			subject.Entries = flattenGradebook(res.scores)
+		}
+		resp.Subjects = append(resp.Subjects, subject)
+	}
+
+	return resp
+}
+
+func buildSummary(results []subjectScores, start, end string) *domain.PerformanceSummaryResponse {
+	summaries := make([]domain.SubjectPerformanceSummary, 0, len(results))
+	var overall scoreAverager
+
+	for _, res := range results {
+		if res.err != nil {
+			summaries = append(summaries, domain.SubjectPerformanceSummary{
+				SuID:  res.subject.SuID,
+				Title: res.subject.Title,
+				Marks: make(map[domain.ScoreKind]int),
+				Error: res.err.Error(),
+			})
+			continue
+		}
+
+		summary, avg := summarizeSubject(res.subject, flattenGradebook(res.scores))
+		summaries = append(summaries, summary)
+		overall.merge(avg)
+	}
+
+	return &domain.PerformanceSummaryResponse{
+		PeriodStart:     start,
+		PeriodEnd:       end,
+		Average:         roundRatio(overall.average()),
+		WeightedAverage: roundRatio(overall.weightedAverage()),
+		Subjects:        summaries,
+	}
+}
+
+func summarizeSubject(subject domain.PerformanceSubject, entries []domain.GradebookEntry) (domain.SubjectPerformanceSummary, scoreAverager) {
+	summary := domain.SubjectPerformanceSummary{
+		SuID:   subject.SuID,
+		Title:  subject.Title,
+		Scores: len(entries),
+		Marks:  make(map[domain.ScoreKind]int),
+	}
+
+	var avg scoreAverager
+	for _, entry := range entries {
+		summary.Marks[entry.Normalized.Kind]++
+		avg.add(entry.Normalized)
+	}
+
+	summary.NumericScores = avg.count
+	summary.Average = roundRatio(avg.average())
+	summary.WeightedAverage = roundRatio(avg.weightedAverage())
+
+	return summary, avg
+}
+
+func (s *PerformanceService) GetScoreBook(login, suID, start, end string) (*domain.ScoreBook, error) {
+	scores, err := s.GetScore(login, suID, start, end)
+	if err != nil {
+		return nil, err
+	}
+
+	return &domain.ScoreBook{
+		SuID:        suID,
+		PeriodStart: start,
+		PeriodEnd:   end,
+		Subjects:    buildScoreBookSubjects(scores),
+	}, nil
+}
+
+func buildScoreBookSubjects(scores map[string]map[string][]domain.PerformanceScore) []domain.ScoreBookSubject {
+	subjects := make([]domain.ScoreBookSubject, 0, len(scores))
+
+	for title, byType := range scores {
+		subject := domain.ScoreBookSubject{
+			Title:     title,
+			WorkTypes: make([]domain.ScoreBookWorkType, 0, len(byType)),
+		}
+
+		for workType, entries := range byType {
+			sorted := make([]domain.PerformanceScore, len(entries))
+			copy(sorted, entries)
+			sort.SliceStable(sorted, func(i, j int) bool {
+				pi, pj := parsePortalDate(sorted[i].DateP), parsePortalDate(sorted[j].DateP)
+				if !pi.Equal(pj) {
+					return pi.Before(pj)
+				}
+				return parsePortalDate(sorted[i].DateF).Before(parsePortalDate(sorted[j].DateF))
+			})
+
+			subject.WorkTypes = append(subject.WorkTypes, domain.ScoreBookWorkType{
+				Title:   workType,
+				Entries: sorted,
+			})
+		}
+
+		sort.Slice(subject.WorkTypes, func(i, j int) bool {
+			return subject.WorkTypes[i].Title < subject.WorkTypes[j].Title
+		})
+		subjects = append(subjects, subject)
+	}
+
+	sort.Slice(subjects, func(i, j int) bool {
+		return subjects[i].Title < subjects[j].Title
+	})
+
+	return subjects
+}
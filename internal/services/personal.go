@@ -0,0 +1,180 @@
+package services
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/anton1ks96/college-app-core/internal/domain"
+	"github.com/anton1ks96/college-app-core/internal/storage"
+)
+
+const personalClIDPrefix = "personal-"
+
+type PersonalService struct {
+	store storage.Storage
+	bells *BellService
+}
+
+func NewPersonalService(store storage.Storage, bells *BellService) *PersonalService {
+	return &PersonalService{
+		store: store,
+		bells: bells,
+	}
+}
+
+func (s *PersonalService) GetNotes(login, clID string) ([]domain.LessonNote, error) {
+	notes, err := s.store.Notes(login)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]domain.LessonNote, 0, len(notes))
+	for _, note := range notes {
+		if clID == "" || note.ClID == clID {
+			result = append(result, note)
+		}
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].CreatedAt.Before(result[j].CreatedAt)
+	})
+
+	return result, nil
+}
+
+func (s *PersonalService) CreateNote(login, clID, text string) (*domain.LessonNote, error) {
+	now := time.Now()
+	return s.store.SaveNote(login, domain.LessonNote{
+		ClID:      clID,
+		Text:      strings.TrimSpace(text),
+		CreatedAt: now,
+		UpdatedAt: now,
+	})
+}
+
+func (s *PersonalService) UpdateNote(login, id, text string) (*domain.LessonNote, bool, error) {
+	note, ok, err := s.store.Note(login, id)
+	if err != nil || !ok {
+		return nil, ok, err
+	}
+
+	note.Text = strings.TrimSpace(text)
+	note.UpdatedAt = time.Now()
+
+	saved, err := s.store.SaveNote(login, *note)
+	if err != nil {
+		return nil, true, err
+	}
+	return saved, true, nil
+}
+
+func (s *PersonalService) DeleteNote(login, id string) (bool, error) {
+	return s.store.DeleteNote(login, id)
+}
+
+func (s *PersonalService) GetEvents(login, start, end string) ([]domain.PersonalEvent, error) {
+	events, err := s.store.PersonalEvents(login)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]domain.PersonalEvent, 0, len(events))
+	for _, e := range events {
+		if start != "" && e.Day < start {
+			continue
+		}
+		if end != "" && e.Day > end {
+			continue
+		}
+		result = append(result, e)
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].Day != result[j].Day {
+			return result[i].Day < result[j].Day
+		}
+		return result[i].Start < result[j].Start
+	})
+
+	return result, nil
+}
+
+func (s *PersonalService) CreateEvent(login string, event domain.PersonalEvent) (*domain.PersonalEvent, error) {
+	now := time.Now()
+	event.ID = ""
+	event.CreatedAt = now
+	event.UpdatedAt = now
+
+	return s.store.SavePersonalEvent(login, event)
+}
+
+func (s *PersonalService) UpdateEvent(login, id string, event domain.PersonalEvent) (*domain.PersonalEvent, bool, error) {
+	existing, ok, err := s.store.PersonalEvent(login, id)
+	if err != nil || !ok {
+		return nil, ok, err
+	}
+
+	event.ID = existing.ID
+	event.CreatedAt = existing.CreatedAt
+	event.UpdatedAt = time.Now()
+
+	saved, err := s.store.SavePersonalEvent(login, event)
+	if err != nil {
+		return nil, true, err
+	}
+	return saved, true, nil
+}
+
+func (s *PersonalService) DeleteEvent(login, id string) (bool, error) {
+	return s.store.DeletePersonalEvent(login, id)
+}
+
+func (s *PersonalService) MergeSchedule(login string, events []domain.ScheduleEvent, start, end string) ([]domain.ScheduleEvent, error) {
+	notes, err := s.GetNotes(login, "")
+	if err != nil {
+		return nil, fmt.Errorf("failed to load notes: %w", err)
+	}
+	personal, err := s.GetEvents(login, start, end)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load personal events: %w", err)
+	}
+
+	byClID := make(map[string][]domain.LessonNote)
+	for _, note := range notes {
+		byClID[note.ClID] = append(byClID[note.ClID], note)
+	}
+
+	result := make([]domain.ScheduleEvent, 0, len(events)+len(personal))
+	for _, ev := range events {
+		ev.Source = domain.ScheduleSourcePortal
+		ev.Notes = byClID[ev.ClID]
+		result = append(result, ev)
+	}
+
+	for _, e := range personal {
+		result = append(result, domain.ScheduleEvent{
+			ClID:   personalClIDPrefix + e.ID,
+			Day:    e.Day,
+			Start:  e.Start,
+			End:    e.End,
+			Room:   e.Room,
+			Title:  e.Title,
+			Topic:  e.Description,
+			Color:  e.Color,
+			Period: s.bells.PeriodNumber(e.Day, e.Start),
+			Source: domain.ScheduleSourcePersonal,
+			Notes:  byClID[personalClIDPrefix+e.ID],
+		})
+	}
+
+	sort.SliceStable(result, func(i, j int) bool {
+		if result[i].Day != result[j].Day {
+			return result[i].Day < result[j].Day
+		}
+		return result[i].Start < result[j].Start
+	})
+
+	return result, nil
+}
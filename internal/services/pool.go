@@ -0,0 +1,57 @@
+package services
+
+import (
+	"sync"
+	"time"
+)
+
+func runBounded(n, workers int, fn func(i int)) {
+	if workers <= 0 || workers > n {
+		workers = n
+	}
+
+	jobs := make(chan int)
+	var wg sync.WaitGroup
+
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range jobs {
+				fn(i)
+			}
+		}()
+	}
+
+	for i := 0; i < n; i++ {
+		jobs <- i
+	}
+	close(jobs)
+
+	wg.Wait()
+}
+
+type rateLimiter struct {
+	ticker *time.Ticker
+}
+
+func newRateLimiter(perSecond int) *rateLimiter {
+	if perSecond <= 0 {
+		return &rateLimiter{}
+	}
+	return &rateLimiter{
+		ticker: time.NewTicker(time.Second / time.Duration(perSecond)),
+	}
+}
+
+func (l *rateLimiter) Wait() {
+	if l.ticker != nil {
+		<-l.ticker.C
+	}
+}
+
+func (l *rateLimiter) Stop() {
+	if l.ticker != nil {
+		l.ticker.Stop()
+	}
+}
@@ -0,0 +1,171 @@
+package services
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/anton1ks96/college-app-core/internal/domain"
+	"github.com/anton1ks96/college-app-core/internal/storage"
+)
+
+type PreferencesService struct {
+	store storage.Storage
+}
+
+func NewPreferencesService(store storage.Storage) *PreferencesService {
+	return &PreferencesService{
+		store: store,
+	}
+}
+
+func (s *PreferencesService) GetPreferences(login string) (*domain.UserPreferences, error) {
+	prefs, ok, err := s.store.Preferences(login)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load preferences: %w", err)
+	}
+	if !ok {
+		prefs = &domain.UserPreferences{}
+	}
+	if prefs.HiddenSubjects == nil {
+		prefs.HiddenSubjects = make([]string, 0)
+	}
+
+	subscribed, err := s.store.IsSubscribed(login)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load grade subscription: %w", err)
+	}
+	prefs.Notifications.Grades = &subscribed
+
+	return prefs, nil
+}
+
+func (s *PreferencesService) UpdatePreferences(login string, prefs domain.UserPreferences) (*domain.UserPreferences, error) {
+	prefs.HiddenSubjects = normalizeTitles(prefs.HiddenSubjects)
+	prefs.UpdatedAt = time.Now()
+	grades := prefs.Notifications.Grades
+	prefs.Notifications.Grades = nil
+
+	if err := s.store.SavePreferences(login, prefs); err != nil {
+		return nil, fmt.Errorf("failed to save preferences: %w", err)
+	}
+
+	if grades != nil {
+		var err error
+		if *grades {
+			err = s.store.Subscribe(login)
+		} else {
+			err = s.store.Unsubscribe(login)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to update grade subscription: %w", err)
+		}
+	}
+
+	subscribed, err := s.store.IsSubscribed(login)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load grade subscription: %w", err)
+	}
+	prefs.Notifications.Grades = &subscribed
+
+	return &prefs, nil
+}
+
+func (s *PreferencesService) ResolveSelection(login string, sel domain.ScheduleSelection) (domain.ScheduleSelection, *domain.UserPreferences, error) {
+	prefs, err := s.GetPreferences(login)
+	if err != nil {
+		return sel, nil, err
+	}
+
+	sel, applied := ApplyPreferences(sel, prefs)
+	if !applied {
+		prefs.HiddenSubjects = nil
+	}
+
+	return sel, prefs, nil
+}
+
+func ApplyPreferences(sel domain.ScheduleSelection, prefs *domain.UserPreferences) (domain.ScheduleSelection, bool) {
+	if prefs == nil {
+		return sel, false
+	}
+
+	switch {
+	case sel.Group == "":
+		sel.Group = prefs.Group
+	case !strings.EqualFold(sel.Group, prefs.Group):
+		return sel, false
+	}
+
+	if sel.Subgroup == "" {
+		sel.Subgroup = prefs.Subgroup
+	}
+	if sel.EnglishGroup == "" {
+		sel.EnglishGroup = prefs.EnglishGroup
+	}
+	if sel.ProfileSubgroup == "" {
+		sel.ProfileSubgroup = prefs.ProfileSubgroup
+	}
+
+	return sel, true
+}
+
+func (s *PreferencesService) Location(prefs *domain.UserPreferences) *time.Location {
+	if prefs == nil || prefs.Timezone == "" {
+		return time.Local
+	}
+	loc, err := time.LoadLocation(prefs.Timezone)
+	if err != nil {
+		return time.Local
+	}
+	return loc
+}
+
+func FilterHiddenSubjects(events []domain.ScheduleEvent, hidden []string) []domain.ScheduleEvent {
+	if len(hidden) == 0 {
+		return events
+	}
+
+	hiddenSet := lowerSet(hidden)
+
+	filtered := make([]domain.ScheduleEvent, 0, len(events))
+	for _, e := range events {
+		if hiddenSet[strings.ToLower(strings.TrimSpace(e.Title))] {
+			continue
+		}
+		filtered = append(filtered, e)
+	}
+	return filtered
+}
+
+func FilterHiddenSubjectsForGroup(events []domain.ScheduleEvent, group string, hidden []string) []domain.ScheduleEvent {
+	if group == "" || len(hidden) == 0 {
+		return events
+	}
+
+	hiddenSet := lowerSet(hidden)
+
+	filtered := make([]domain.ScheduleEvent, 0, len(events))
+	for _, e := range events {
+		if strings.EqualFold(e.SourceGroup, group) && hiddenSet[strings.ToLower(strings.TrimSpace(e.Title))] {
+			continue
+		}
+		filtered = append(filtered, e)
+	}
+	return filtered
+}
+
+func normalizeTitles(titles []string) []string {
+	seen := make(map[string]bool, len(titles))
+	result := make([]string, 0, len(titles))
+	for _, title := range titles {
+		title = strings.TrimSpace(title)
+		key := strings.ToLower(title)
+		if title == "" || seen[key] {
+			continue
+		}
+		seen[key] = true
+		result = append(result, title)
+	}
+	return result
+}
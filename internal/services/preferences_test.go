@@ -0,0 +1,197 @@
+package services
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/anton1ks96/college-app-core/internal/domain"
+	"github.com/anton1ks96/college-app-core/internal/storage"
+)
+
+func newTestStore(t *testing.T) *storage.BoltStorage {
+	t.Helper()
+
+	store, err := storage.NewBoltStorage(filepath.Join(t.TempDir(), "test.db"), time.Second, 0)
+	if err != nil {
+		t.Fatalf("failed to open storage: %v", err)
+	}
+	t.Cleanup(func() { store.Close() })
+	return store
+}
+
+func boolPtr(v bool) *bool {
+	return &v
+}
+
+func TestUpdatePreferencesSubscription(t *testing.T) {
+	tests := []struct {
+		name           string
+		subscribed     bool
+		grades         *bool
+		wantSubscribed bool
+		wantSeenScores bool
+	}{
+		{name: "field omitted keeps subscription", subscribed: true, grades: nil, wantSubscribed: true, wantSeenScores: true},
+		{name: "field omitted keeps unsubscribed", subscribed: false, grades: nil, wantSubscribed: false, wantSeenScores: true},
+		{name: "explicit subscribe", subscribed: false, grades: boolPtr(true), wantSubscribed: true, wantSeenScores: true},
+		{name: "explicit unsubscribe clears seen scores", subscribed: true, grades: boolPtr(false), wantSubscribed: false, wantSeenScores: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store := newTestStore(t)
+			if tt.subscribed {
+				if err := store.Subscribe("student"); err != nil {
+					t.Fatal(err)
+				}
+			}
+			if err := store.SaveSeenScores("student", map[string]string{"1": "5"}); err != nil {
+				t.Fatal(err)
+			}
+
+			svc := NewPreferencesService(store)
+			prefs, err := svc.UpdatePreferences("student", domain.UserPreferences{
+				Group:         "ИСП-21",
+				Notifications: domain.NotificationPreferences{Grades: tt.grades},
+			})
+			if err != nil {
+				t.Fatalf("UpdatePreferences: %v", err)
+			}
+			if prefs.Notifications.Grades == nil || *prefs.Notifications.Grades != tt.wantSubscribed {
+				t.Errorf("response grades = %v, want %v", prefs.Notifications.Grades, tt.wantSubscribed)
+			}
+
+			subscribed, err := store.IsSubscribed("student")
+			if err != nil {
+				t.Fatal(err)
+			}
+			if subscribed != tt.wantSubscribed {
+				t.Errorf("subscribed = %v, want %v", subscribed, tt.wantSubscribed)
+			}
+
+			_, found, err := store.SeenScores("student")
+			if err != nil {
+				t.Fatal(err)
+			}
+			if found != tt.wantSeenScores {
+				t.Errorf("seen scores kept = %v, want %v", found, tt.wantSeenScores)
+			}
+		})
+	}
+}
+
+func TestResolveSelection(t *testing.T) {
+	store := newTestStore(t)
+	err := store.SavePreferences("student", domain.UserPreferences{
+		Group:          "ИСП-21",
+		Subgroup:       "Подгр1",
+		EnglishGroup:   "B1.02",
+		Timezone:       "Asia/Yekaterinburg",
+		HiddenSubjects: []string{"Физкультура"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	svc := NewPreferencesService(store)
+
+	tests := []struct {
+		name       string
+		sel        domain.ScheduleSelection
+		want       domain.ScheduleSelection
+		wantHidden int
+	}{
+		{
+			name:       "empty query uses saved selection",
+			want:       domain.ScheduleSelection{Group: "ИСП-21", Subgroup: "Подгр1", EnglishGroup: "B1.02"},
+			wantHidden: 1,
+		},
+		{
+			name:       "same group fills missing subgroups",
+			sel:        domain.ScheduleSelection{Group: "исп-21", Subgroup: "Подгр2"},
+			want:       domain.ScheduleSelection{Group: "исп-21", Subgroup: "Подгр2", EnglishGroup: "B1.02"},
+			wantHidden: 1,
+		},
+		{
+			name:       "other group ignores saved selection and hidden subjects",
+			sel:        domain.ScheduleSelection{Group: "ИСП-22"},
+			want:       domain.ScheduleSelection{Group: "ИСП-22"},
+			wantHidden: 0,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, prefs, err := svc.ResolveSelection("student", tt.sel)
+			if err != nil {
+				t.Fatalf("ResolveSelection: %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("selection = %+v, want %+v", got, tt.want)
+			}
+			if len(prefs.HiddenSubjects) != tt.wantHidden {
+				t.Errorf("hidden subjects = %v, want %d", prefs.HiddenSubjects, tt.wantHidden)
+			}
+			if prefs.Timezone != "Asia/Yekaterinburg" {
+				t.Errorf("timezone = %q, want the saved one", prefs.Timezone)
+			}
+		})
+	}
+}
+
+func TestApplyPreferences(t *testing.T) {
+	prefs := &domain.UserPreferences{Group: "ИСП-21", Subgroup: "Подгр1", EnglishGroup: "B1.02", ProfileSubgroup: "Проф1"}
+
+	tests := []struct {
+		name    string
+		sel     domain.ScheduleSelection
+		prefs   *domain.UserPreferences
+		want    domain.ScheduleSelection
+		applied bool
+	}{
+		{
+			name:    "saved group fills blanks",
+			sel:     domain.ScheduleSelection{Group: "ИСП-21", EnglishGroup: "A2.01"},
+			prefs:   prefs,
+			want:    domain.ScheduleSelection{Group: "ИСП-21", Subgroup: "Подгр1", EnglishGroup: "A2.01", ProfileSubgroup: "Проф1"},
+			applied: true,
+		},
+		{
+			name:  "other group untouched",
+			sel:   domain.ScheduleSelection{Group: "ИСП-22", Subgroup: "Подгр2"},
+			prefs: prefs,
+			want:  domain.ScheduleSelection{Group: "ИСП-22", Subgroup: "Подгр2"},
+		},
+		{
+			name: "no preferences",
+			sel:  domain.ScheduleSelection{Group: "ИСП-21"},
+			want: domain.ScheduleSelection{Group: "ИСП-21"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, applied := ApplyPreferences(tt.sel, tt.prefs)
+			if got != tt.want || applied != tt.applied {
+				t.Errorf("ApplyPreferences = %+v, %v; want %+v, %v", got, applied, tt.want, tt.applied)
+			}
+		})
+	}
+}
+
+func TestFilterHiddenSubjectsForGroup(t *testing.T) {
+	events := []domain.ScheduleEvent{
+		{ClID: "1", SourceGroup: "ИСП-21", Title: "Физкультура"},
+		{ClID: "2", SourceGroup: "ИСП-21", Title: "Математика"},
+		{ClID: "3", SourceGroup: "ИСП-22", Title: "Физкультура"},
+	}
+
+	got := FilterHiddenSubjectsForGroup(events, "исп-21", []string{" физкультура "})
+	if len(got) != 2 || got[0].ClID != "2" || got[1].ClID != "3" {
+		t.Fatalf("filtered = %+v, want events 2 and 3", got)
+	}
+
+	if got := FilterHiddenSubjectsForGroup(events, "", []string{"Физкультура"}); len(got) != len(events) {
+		t.Errorf("filtered %d events without a saved group, want none", len(events)-len(got))
+	}
+}
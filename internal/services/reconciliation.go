@@ -0,0 +1,166 @@
+package services
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/anton1ks96/college-app-core/internal/domain"
+)
+
+type ReconciliationService struct {
+	schedule   *ScheduleService
+	attendance *AttendanceService
+}
+
+func NewReconciliationService(schedule *ScheduleService, attendance *AttendanceService) *ReconciliationService {
+	return &ReconciliationService{
+		schedule:   schedule,
+		attendance: attendance,
+	}
+}
+
+func (s *ReconciliationService) Reconcile(login string, sel domain.ScheduleSelection, start, end string) (*domain.ReconciliationReport, error) {
+	events, err := s.schedule.GetSchedule(sel.Group, sel.Subgroup, sel.EnglishGroup, sel.ProfileSubgroup, start, end)
+	if err != nil {
+		return nil, err
+	}
+
+	records, err := s.attendance.GetAttendance(login, start, end)
+	if err != nil {
+		return nil, err
+	}
+
+	report := reconcile(events, records, time.Now())
+	report.PeriodStart = start
+	report.PeriodEnd = end
+
+	return report, nil
+}
+
+func reconcile(events []domain.ScheduleEvent, records []domain.AttendanceRecord, now time.Time) *domain.ReconciliationReport {
+	report := &domain.ReconciliationReport{
+		MissingMarks: make([]domain.ReconciliationEntry, 0),
+		Unscheduled:  make([]domain.ReconciliationEntry, 0),
+		Mismatches:   make([]domain.ReconciliationMismatch, 0),
+	}
+
+	marked := make(map[string]domain.AttendanceRecord, len(records))
+	for _, r := range records {
+		marked[reconcileKey(r.Day, strconv.Itoa(r.ClID))] = r
+	}
+
+	scheduled := make(map[string]bool, len(events))
+	for _, ev := range events {
+		key := reconcileKey(ev.Day, ev.ClID)
+		scheduled[key] = true
+
+		r, ok := marked[key]
+		if !ok || !r.StatusName.Marked() {
+			if classFinished(ev.Day, ev.End, now) {
+				entry := domain.ReconciliationEntry{
+					ClID:  ev.ClID,
+					Day:   ev.Day,
+					Start: ev.Start,
+					End:   ev.End,
+					Title: ev.Title,
+					Room:  ev.Room,
+				}
+				if ok {
+					entry.Status = r.StatusName
+				}
+				report.MissingMarks = append(report.MissingMarks, entry)
+			}
+		}
+		if !ok {
+			continue
+		}
+
+		titles := []string{ev.Title}
+		rooms := []string{ev.Room}
+		for _, sg := range ev.SubGroup {
+			titles = append(titles, sg.STitle)
+			rooms = append(rooms, sg.SGCaID)
+		}
+
+		if !matchesAny(r.Title, titles) {
+			report.Mismatches = append(report.Mismatches, domain.ReconciliationMismatch{
+				ClID:      ev.ClID,
+				Day:       ev.Day,
+				Field:     "title",
+				Scheduled: ev.Title,
+				Marked:    r.Title,
+			})
+		}
+		if ev.Room != "" && r.Room != "" && !matchesAny(r.Room, rooms) {
+			report.Mismatches = append(report.Mismatches, domain.ReconciliationMismatch{
+				ClID:      ev.ClID,
+				Day:       ev.Day,
+				Field:     "room",
+				Scheduled: ev.Room,
+				Marked:    r.Room,
+			})
+		}
+	}
+
+	for _, r := range records {
+		if scheduled[reconcileKey(r.Day, strconv.Itoa(r.ClID))] {
+			continue
+		}
+		report.Unscheduled = append(report.Unscheduled, domain.ReconciliationEntry{
+			ClID:   strconv.Itoa(r.ClID),
+			Day:    r.Day,
+			Start:  r.Start,
+			End:    r.End,
+			Title:  r.Title,
+			Room:   r.Room,
+			Status: r.StatusName,
+		})
+	}
+
+	sort.SliceStable(report.Unscheduled, func(i, j int) bool {
+		if report.Unscheduled[i].Day != report.Unscheduled[j].Day {
+			return report.Unscheduled[i].Day < report.Unscheduled[j].Day
+		}
+		return report.Unscheduled[i].Start < report.Unscheduled[j].Start
+	})
+
+	return report
+}
+
+func matchesAny(value string, candidates []string) bool {
+	value = strings.TrimSpace(value)
+	for _, candidate := range candidates {
+		if strings.EqualFold(strings.TrimSpace(candidate), value) {
+			return true
+		}
+	}
+	return false
+}
+
+func reconcileKey(day, clid string) string {
+	return fmt.Sprintf("%s|%s", day, clid)
+}
+
+func classFinished(day, end string, now time.Time) bool {
+	date, err := time.ParseInLocation(dateLayout, day, time.Local)
+	if err != nil {
+		return false
+	}
+
+	today := truncateDay(now)
+	if date.Before(today) {
+		return true
+	}
+	if date.After(today) {
+		return false
+	}
+
+	minutes, ok := parseClock(end)
+	if !ok {
+		return false
+	}
+	return now.Hour()*60+now.Minute() >= minutes
+}
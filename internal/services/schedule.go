@@ -5,18 +5,29 @@ import (
 	"regexp"
 	"sort"
 	"strings"
+	"time"
 
 	"github.com/anton1ks96/college-app-core/internal/domain"
 	"github.com/anton1ks96/college-app-core/internal/repository"
+	"github.com/anton1ks96/college-app-core/internal/storage"
+	"github.com/anton1ks96/college-app-core/pkg/logger"
 )
 
 type ScheduleService struct {
-	portal *repository.PortalRepository
+	portal  *repository.PortalRepository
+	bells   *BellService
+	store   storage.Storage
+	history *HistoryRecorder
+	workers int
 }
 
-func NewScheduleService(portal *repository.PortalRepository) *ScheduleService {
+func NewScheduleService(portal *repository.PortalRepository, bells *BellService, store storage.Storage, history *HistoryRecorder, workers int) *ScheduleService {
 	return &ScheduleService{
-		portal: portal,
+		portal:  portal,
+		bells:   bells,
+		store:   store,
+		history: history,
+		workers: workers,
 	}
 }
 
@@ -26,9 +37,9 @@ func (s *ScheduleService) GetSchedule(group, subgroup, englishGroup, profileSubg
 	req := domain.ScheduleRequest{
 		DStart: start, DEnd: end, Group: group, Subgroup: "*",
 	}
-	events, err := s.portal.FetchSchedule(req)
+	events, err := s.fetchSchedule(req)
 	if err != nil {
-		return nil, fmt.Errorf("failed to fetch schedule: %w", err)
+		return nil, err
 	}
 
 	result := filterEventsForSelection(events, subgroup, englishGroup, profileSubgroup)
@@ -38,6 +49,7 @@ func (s *ScheduleService) GetSchedule(group, subgroup, englishGroup, profileSubg
 			if len(result[i].SubGroup) == 1 {
 				sg := result[i].SubGroup[0]
 				result[i].Title = sg.STitle
+				result[i].SubGroupID = sg.SGrID
 
 				if result[i].Topic == "" {
 					result[i].Topic = sg.STopic
@@ -50,6 +62,10 @@ func (s *ScheduleService) GetSchedule(group, subgroup, englishGroup, profileSubg
 		}
 	}
 
+	for i := range result {
+		result[i].Period = s.bells.PeriodNumber(result[i].Day, result[i].Start)
+	}
+
 	sort.Slice(result, func(i, j int) bool {
 		if result[i].Day != result[j].Day {
 			return result[i].Day < result[j].Day
@@ -57,9 +73,83 @@ func (s *ScheduleService) GetSchedule(group, subgroup, englishGroup, profileSubg
 		return result[i].Start < result[j].Start
 	})
 
+	if subgroup != "" && subgroup != "*" {
+		markConflicts(result, detectConflicts(result))
+	}
+
 	return result, nil
 }
 
+func (s *ScheduleService) fetchSchedule(req domain.ScheduleRequest) ([]domain.ScheduleEvent, error) {
+	events, err := s.portal.FetchSchedule(req)
+	if err == nil {
+		snapshot := domain.ScheduleSnapshot{
+			Group:   req.Group,
+			Start:   req.DStart,
+			End:     req.DEnd,
+			Events:  events,
+			SavedAt: time.Now(),
+		}
+		s.history.SaveScheduleSnapshot(snapshot)
+		return events, nil
+	}
+
+	snapshot, ok, snapshotErr := s.store.ScheduleSnapshot(req.Group, req.DStart, req.DEnd)
+	if snapshotErr != nil || !ok {
+		return nil, fmt.Errorf("failed to fetch schedule: %w", err)
+	}
+
+	logger.Logger.Warn().
+		Err(err).
+		Str("group", req.Group).
+		Time("saved_at", snapshot.SavedAt).
+		Msg("portal unavailable, serving stale schedule snapshot")
+
+	return snapshot.Events, nil
+}
+
+func (s *ScheduleService) GetMergedSchedule(selections []domain.ScheduleSelection, start, end string) ([]domain.ScheduleEvent, error) {
+	results := make([][]domain.ScheduleEvent, len(selections))
+	errs := make([]error, len(selections))
+
+	runBounded(len(selections), s.workers, func(i int) {
+		sel := selections[i]
+		events, err := s.GetSchedule(sel.Group, sel.Subgroup, sel.EnglishGroup, sel.ProfileSubgroup, start, end)
+		if err != nil {
+			errs[i] = fmt.Errorf("group %s: %w", sel.Group, err)
+			return
+		}
+		for j := range events {
+			events[j].SourceGroup = sel.Group
+		}
+		results[i] = events
+	})
+
+	for _, err := range errs {
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	merged := make([]domain.ScheduleEvent, 0)
+	for _, events := range results {
+		merged = append(merged, events...)
+	}
+
+	sort.SliceStable(merged, func(i, j int) bool {
+		if merged[i].Day != merged[j].Day {
+			return merged[i].Day < merged[j].Day
+		}
+		return merged[i].Start < merged[j].Start
+	})
+
+	return merged, nil
+}
+
+func isPESection(sgrID string) bool {
+	return strings.EqualFold(sgrID, "ФизраКол") || strings.EqualFold(sgrID, "БрайтФит") || strings.EqualFold(sgrID, "БаскетКол")
+}
+
 func filterEventsForSelection(events []domain.ScheduleEvent, subgroup, englishGroup, profileSubgroup string) []domain.ScheduleEvent {
 	if subgroup == "" || subgroup == "*" {
 		return events
@@ -75,7 +165,7 @@ func filterEventsForSelection(events []domain.ScheduleEvent, subgroup, englishGr
 
 		filtered := ev.SubGroup[:0]
 		for _, sg := range ev.SubGroup {
-			if strings.EqualFold(sg.SGrID, "ФизраКол") || strings.EqualFold(sg.SGrID, "БрайтФит") || strings.EqualFold(sg.SGrID, "БаскетКол") {
+			if isPESection(sg.SGrID) {
 				filtered = append(filtered, sg)
 				continue
 			}
@@ -122,7 +212,3 @@ func filterEventsForSelection(events []domain.ScheduleEvent, subgroup, englishGr
 
 	return out
 }
-
-func (s *ScheduleService) GetClassDetails(clid string) (map[string]any, error) {
-	return s.portal.FetchClassDetails(clid)
-}
@@ -0,0 +1,125 @@
+package services
+
+import (
+	"fmt"
+
+	"github.com/anton1ks96/college-app-core/internal/domain"
+)
+
+type conflictEntry struct {
+	entry domain.ScheduleConflictEntry
+	event int
+	start int
+	end   int
+	pe    bool
+}
+
+func (s *ScheduleService) GetScheduleConflicts(group, subgroup, englishGroup, profileSubgroup, start, end string) ([]domain.ScheduleConflict, error) {
+	if subgroup == "" || subgroup == "*" {
+		return nil, fmt.Errorf("subgroup is required to detect conflicts")
+	}
+
+	events, err := s.GetSchedule(group, subgroup, englishGroup, profileSubgroup, start, end)
+	if err != nil {
+		return nil, err
+	}
+
+	return detectConflicts(events), nil
+}
+
+func detectConflicts(events []domain.ScheduleEvent) []domain.ScheduleConflict {
+	byDay := make(map[string][]conflictEntry)
+	days := make([]string, 0)
+
+	for i, ev := range events {
+		start, okStart := parseClock(ev.Start)
+		end, okEnd := parseClock(ev.End)
+		if !okStart || !okEnd {
+			continue
+		}
+
+		if _, exists := byDay[ev.Day]; !exists {
+			days = append(days, ev.Day)
+		}
+
+		if len(ev.SubGroup) == 0 {
+			byDay[ev.Day] = append(byDay[ev.Day], conflictEntry{
+				entry: domain.ScheduleConflictEntry{
+					ClID:  ev.ClID,
+					Title: ev.Title,
+					Start: ev.Start,
+					End:   ev.End,
+					Room:  ev.Room,
+				},
+				event: i,
+				start: start,
+				end:   end,
+			})
+			continue
+		}
+
+		for _, sg := range ev.SubGroup {
+			room := sg.SGCaID
+			if room == "" {
+				room = ev.Room
+			}
+			byDay[ev.Day] = append(byDay[ev.Day], conflictEntry{
+				entry: domain.ScheduleConflictEntry{
+					ClID:  ev.ClID,
+					SGrID: sg.SGrID,
+					Title: sg.STitle,
+					Start: ev.Start,
+					End:   ev.End,
+					Room:  room,
+				},
+				event: i,
+				start: start,
+				end:   end,
+				pe:    isPESection(sg.SGrID),
+			})
+		}
+	}
+
+	conflicts := make([]domain.ScheduleConflict, 0)
+	for _, day := range days {
+		entries := byDay[day]
+		for i := 0; i < len(entries); i++ {
+			for j := i + 1; j < len(entries); j++ {
+				a, b := entries[i], entries[j]
+				if a.event == b.event || a.entry.ClID == b.entry.ClID {
+					continue
+				}
+				if a.pe && b.pe {
+					continue
+				}
+				if a.start < b.end && b.start < a.end {
+					conflicts = append(conflicts, domain.ScheduleConflict{
+						Day:    day,
+						First:  a.entry,
+						Second: b.entry,
+					})
+				}
+			}
+		}
+	}
+
+	return conflicts
+}
+
+func markConflicts(events []domain.ScheduleEvent, conflicts []domain.ScheduleConflict) {
+	if len(conflicts) == 0 {
+		return
+	}
+
+	flagged := make(map[string]bool)
+	for _, c := range conflicts {
+		flagged[c.Day+"|"+c.First.ClID] = true
+		flagged[c.Day+"|"+c.Second.ClID] = true
+	}
+
+	for i := range events {
+		if flagged[events[i].Day+"|"+events[i].ClID] {
+			events[i].Conflict = true
+		}
+	}
+}
@@ -0,0 +1,121 @@
+package services
+
+import (
+	"testing"
+
+	"github.com/anton1ks96/college-app-core/internal/domain"
+)
+
+func TestDetectConflicts(t *testing.T) {
+	lesson := func(clID, day, start, end string, subgroups ...domain.SubGroup) domain.ScheduleEvent {
+		return domain.ScheduleEvent{ClID: clID, Day: day, Start: start, End: end, Title: "lesson " + clID, SubGroup: subgroups}
+	}
+	sg := func(clID, grID string) domain.SubGroup {
+		return domain.SubGroup{SClID: clID, SGrID: grID, STitle: grID}
+	}
+
+	tests := []struct {
+		name    string
+		events  []domain.ScheduleEvent
+		want    int
+		flagged []string
+	}{
+		{
+			name:   "no events",
+			events: nil,
+			want:   0,
+		},
+		{
+			name: "english group alternatives of one lesson",
+			events: []domain.ScheduleEvent{
+				lesson("1", "2026-09-07", "09:00", "10:30", sg("11", "A2.01"), sg("12", "B1.02")),
+			},
+			want: 0,
+		},
+		{
+			name: "profile subgroup alternatives of one lesson",
+			events: []domain.ScheduleEvent{
+				lesson("1", "2026-09-07", "09:00", "10:30", sg("11", "Подгр1"), sg("12", "Подгр2"), sg("13", "Подгр3")),
+			},
+			want: 0,
+		},
+		{
+			name: "same ClID listed twice",
+			events: []domain.ScheduleEvent{
+				lesson("1", "2026-09-07", "09:00", "10:30"),
+				lesson("1", "2026-09-07", "09:00", "10:30"),
+			},
+			want: 0,
+		},
+		{
+			name: "distinct overlapping lessons",
+			events: []domain.ScheduleEvent{
+				lesson("1", "2026-09-07", "09:00", "10:30"),
+				lesson("2", "2026-09-07", "10:00", "11:30"),
+			},
+			want:    1,
+			flagged: []string{"1", "2"},
+		},
+		{
+			name: "adjacent lessons do not overlap",
+			events: []domain.ScheduleEvent{
+				lesson("1", "2026-09-07", "09:00", "10:30"),
+				lesson("2", "2026-09-07", "10:30", "12:00"),
+			},
+			want: 0,
+		},
+		{
+			name: "overlap on different days",
+			events: []domain.ScheduleEvent{
+				lesson("1", "2026-09-07", "09:00", "10:30"),
+				lesson("2", "2026-09-08", "09:00", "10:30"),
+			},
+			want: 0,
+		},
+		{
+			name: "subgroup lesson overlaps another event",
+			events: []domain.ScheduleEvent{
+				lesson("1", "2026-09-07", "09:00", "10:30", sg("11", "A2.01"), sg("12", "B1.02")),
+				lesson("2", "2026-09-07", "09:00", "10:30"),
+			},
+			want:    2,
+			flagged: []string{"1", "2"},
+		},
+		{
+			name: "two PE sections in different events",
+			events: []domain.ScheduleEvent{
+				lesson("1", "2026-09-07", "09:00", "10:30", sg("11", "ФизраКол")),
+				lesson("2", "2026-09-07", "09:00", "10:30", sg("21", "БрайтФит")),
+			},
+			want: 0,
+		},
+		{
+			name: "invalid clock is ignored",
+			events: []domain.ScheduleEvent{
+				lesson("1", "2026-09-07", "bad", "10:30"),
+				lesson("2", "2026-09-07", "09:00", "10:30"),
+			},
+			want: 0,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			conflicts := detectConflicts(tt.events)
+			if len(conflicts) != tt.want {
+				t.Fatalf("got %d conflicts, want %d: %+v", len(conflicts), tt.want, conflicts)
+			}
+
+			markConflicts(tt.events, conflicts)
+			want := make(map[string]bool, len(tt.flagged))
+			for _, clID := range tt.flagged {
+				want[clID] = true
+			}
+			for _, ev := range tt.events {
+				if ev.Conflict != want[ev.ClID] {
+					t.Errorf("event %s conflict = %v, want %v", ev.ClID, ev.Conflict, want[ev.ClID])
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,59 @@
+package services
+
+import (
+	"strings"
+	"time"
+
+	"github.com/anton1ks96/college-app-core/internal/domain"
+	"github.com/anton1ks96/college-app-core/pkg/ical"
+)
+
+const icalUIDDomain = "college-app-core"
+
+func ScheduleCalendarEvents(events []domain.ScheduleEvent, loc *time.Location) []ical.Event {
+	result := make([]ical.Event, 0, len(events))
+
+	for _, ev := range events {
+		start, okStart := lessonTime(ev.Day, ev.Start, loc)
+		end, okEnd := lessonTime(ev.Day, ev.End, loc)
+		if !okStart || !okEnd {
+			continue
+		}
+
+		description := make([]string, 0, len(ev.Notes)+1)
+		if ev.Topic != "" {
+			description = append(description, ev.Topic)
+		}
+		for _, note := range ev.Notes {
+			description = append(description, note.Text)
+		}
+
+		event := ical.Event{
+			UID:         strings.Join([]string{ev.ClID, ev.Day, ev.Start}, "-") + "@" + icalUIDDomain,
+			Summary:     ev.Title,
+			Description: strings.Join(description, "\n\n"),
+			Location:    ev.Room,
+			Start:       start,
+			End:         end,
+		}
+		if ev.Source != "" {
+			event.Categories = []string{string(ev.Source)}
+		}
+
+		result = append(result, event)
+	}
+
+	return result
+}
+
+func lessonTime(day, clock string, loc *time.Location) (time.Time, bool) {
+	date, err := time.ParseInLocation(dateLayout, day, loc)
+	if err != nil {
+		return time.Time{}, false
+	}
+	minutes, ok := parseClock(clock)
+	if !ok {
+		return time.Time{}, false
+	}
+	return date.Add(time.Duration(minutes) * time.Minute), true
+}
@@ -0,0 +1,148 @@
+package services
+
+import (
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/anton1ks96/college-app-core/internal/domain"
+)
+
+const defaultMaxScore = 5
+
+var nonNumericMarks = map[string]domain.ScoreKind{
+	"зач":        domain.ScoreKindPass,
+	"зачет":      domain.ScoreKindPass,
+	"зачёт":      domain.ScoreKindPass,
+	"зачтено":    domain.ScoreKindPass,
+	"незач":      domain.ScoreKindFail,
+	"не зач":     domain.ScoreKindFail,
+	"незачет":    domain.ScoreKindFail,
+	"незачёт":    domain.ScoreKindFail,
+	"не зачтено": domain.ScoreKindFail,
+	"нз":         domain.ScoreKindFail,
+	"н":          domain.ScoreKindAbsent,
+	"нб":         domain.ScoreKindAbsent,
+	"н/я":        domain.ScoreKindAbsent,
+	"неявка":     domain.ScoreKindAbsent,
+	"осв":        domain.ScoreKindExempt,
+	"освобожден": domain.ScoreKindExempt,
+	"освобождён": domain.ScoreKindExempt,
+}
+
+func normalizeScore(score domain.PerformanceScore) domain.NormalizedScore {
+	result := domain.NormalizedScore{
+		Raw:      score.Score,
+		Kind:     domain.ScoreKindUnknown,
+		MaxScore: score.MaxScore,
+	}
+	if result.MaxScore <= 0 {
+		result.MaxScore = defaultMaxScore
+	}
+
+	value := strings.ToLower(strings.TrimSpace(score.Score))
+	value = strings.TrimSuffix(value, ".")
+
+	if kind, ok := nonNumericMarks[value]; ok {
+		result.Kind = kind
+		return result
+	}
+
+	parsed, err := strconv.ParseFloat(strings.ReplaceAll(value, ",", "."), 64)
+	if err != nil || parsed < 0 {
+		return result
+	}
+
+	result.Kind = domain.ScoreKindNumeric
+	result.Value = parsed
+	result.Ratio = parsed / float64(result.MaxScore)
+
+	return result
+}
+
+type scoreAverager struct {
+	count    int
+	ratioSum float64
+	valueSum float64
+	maxSum   float64
+}
+
+func (a *scoreAverager) add(score domain.NormalizedScore) {
+	if score.Kind != domain.ScoreKindNumeric {
+		return
+	}
+	a.count++
+	a.ratioSum += score.Ratio
+	a.valueSum += score.Value
+	a.maxSum += float64(score.MaxScore)
+}
+
+func (a *scoreAverager) merge(other scoreAverager) {
+	a.count += other.count
+	a.ratioSum += other.ratioSum
+	a.valueSum += other.valueSum
+	a.maxSum += other.maxSum
+}
+
+func (a *scoreAverager) average() float64 {
+	if a.count == 0 {
+		return 0
+	}
+	return a.ratioSum / float64(a.count)
+}
+
+func (a *scoreAverager) weightedAverage() float64 {
+	if a.maxSum == 0 {
+		return 0
+	}
+	return a.valueSum / a.maxSum
+}
+
+func flattenGradebook(scores map[string]map[string][]domain.PerformanceScore) []domain.GradebookEntry {
+	result := make([]domain.GradebookEntry, 0)
+	for subject, byType := range scores {
+		for workType, entries := range byType {
+			for _, score := range entries {
+				result = append(result, domain.GradebookEntry{
+					Subject:     subject,
+					WorkType:    workType,
+					DateF:       score.DateF,
+					DateP:       score.DateP,
+					Score:       score.Score,
+					MaxScore:    score.MaxScore,
+					Description: score.Description,
+					Normalized:  normalizeScore(score),
+				})
+			}
+		}
+	}
+
+	sort.SliceStable(result, func(i, j int) bool {
+		di, dj := parsePortalDate(result[i].DateP), parsePortalDate(result[j].DateP)
+		if !di.Equal(dj) {
+			return di.Before(dj)
+		}
+		if result[i].WorkType != result[j].WorkType {
+			return result[i].WorkType < result[j].WorkType
+		}
+		return result[i].Description < result[j].Description
+	})
+
+	return result
+}
+
+func parsePortalDate(value string) time.Time {
+	value = strings.TrimSpace(value)
+	for _, layout := range []string{"2006-01-02", "2006-01-02 15:04:05", "02.01.2006", "02.01.2006 15:04"} {
+		if t, err := time.ParseInLocation(layout, value, time.Local); err == nil {
+			return t
+		}
+	}
+	return time.Time{}
+}
+
+func roundRatio(v float64) float64 {
+	return math.Round(v*1000) / 1000
+}
@@ -0,0 +1,103 @@
+package services
+
+import (
+	"testing"
+
+	"github.com/anton1ks96/college-app-core/internal/domain"
+)
+
+func TestNormalizeScore(t *testing.T) {
+	tests := []struct {
+		score domain.PerformanceScore
+		want  domain.NormalizedScore
+	}{
+		{
+			score: domain.PerformanceScore{Score: "4", MaxScore: 5},
+			want:  domain.NormalizedScore{Raw: "4", Kind: domain.ScoreKindNumeric, Value: 4, MaxScore: 5, Ratio: 0.8},
+		},
+		{
+			score: domain.PerformanceScore{Score: " 7,5 ", MaxScore: 10},
+			want:  domain.NormalizedScore{Raw: " 7,5 ", Kind: domain.ScoreKindNumeric, Value: 7.5, MaxScore: 10, Ratio: 0.75},
+		},
+		{
+			score: domain.PerformanceScore{Score: "3"},
+			want:  domain.NormalizedScore{Raw: "3", Kind: domain.ScoreKindNumeric, Value: 3, MaxScore: 5, Ratio: 0.6},
+		},
+		{
+			score: domain.PerformanceScore{Score: "Зачёт", MaxScore: 5},
+			want:  domain.NormalizedScore{Raw: "Зачёт", Kind: domain.ScoreKindPass, MaxScore: 5},
+		},
+		{
+			score: domain.PerformanceScore{Score: "не зач.", MaxScore: 5},
+			want:  domain.NormalizedScore{Raw: "не зач.", Kind: domain.ScoreKindFail, MaxScore: 5},
+		},
+		{
+			score: domain.PerformanceScore{Score: "Н", MaxScore: 5},
+			want:  domain.NormalizedScore{Raw: "Н", Kind: domain.ScoreKindAbsent, MaxScore: 5},
+		},
+		{
+			score: domain.PerformanceScore{Score: "осв", MaxScore: 5},
+			want:  domain.NormalizedScore{Raw: "осв", Kind: domain.ScoreKindExempt, MaxScore: 5},
+		},
+		{
+			score: domain.PerformanceScore{Score: "-1", MaxScore: 5},
+			want:  domain.NormalizedScore{Raw: "-1", Kind: domain.ScoreKindUnknown, MaxScore: 5},
+		},
+		{
+			score: domain.PerformanceScore{Score: "", MaxScore: 5},
+			want:  domain.NormalizedScore{Kind: domain.ScoreKindUnknown, MaxScore: 5},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.score.Score, func(t *testing.T) {
+			if got := normalizeScore(tt.score); got != tt.want {
+				t.Errorf("normalizeScore(%q) = %+v, want %+v", tt.score.Score, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestScoreAverager(t *testing.T) {
+	var a scoreAverager
+	a.add(normalizeScore(domain.PerformanceScore{Score: "5", MaxScore: 5}))
+	a.add(normalizeScore(domain.PerformanceScore{Score: "5", MaxScore: 10}))
+	a.add(normalizeScore(domain.PerformanceScore{Score: "зач", MaxScore: 5}))
+
+	if a.count != 2 {
+		t.Fatalf("count = %d, want 2", a.count)
+	}
+	if got := a.average(); got != 0.75 {
+		t.Errorf("average = %v, want 0.75", got)
+	}
+	if got := roundRatio(a.weightedAverage()); got != 0.667 {
+		t.Errorf("weighted average = %v, want 0.667", got)
+	}
+
+	var empty scoreAverager
+	if empty.average() != 0 || empty.weightedAverage() != 0 {
+		t.Error("empty averager should report zero")
+	}
+}
+
+func TestFlattenGradebookOrder(t *testing.T) {
+	got := flattenGradebook(map[string]map[string][]domain.PerformanceScore{
+		"Math": {
+			"test": {{DateP: "10.09.2026", Score: "5"}},
+			"hw":   {{DateP: "2026-09-08", Score: "4"}},
+		},
+		"Physics": {
+			"lab": {{DateP: "10.09.2026", Score: "3"}},
+		},
+	})
+
+	want := []string{"hw", "lab", "test"}
+	if len(got) != len(want) {
+		t.Fatalf("got %d entries, want %d", len(got), len(want))
+	}
+	for i, workType := range want {
+		if got[i].WorkType != workType {
+			t.Errorf("entry %d = %s, want %s", i, got[i].WorkType, workType)
+		}
+	}
+}
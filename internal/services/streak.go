@@ -8,75 +8,180 @@ import (
 	"github.com/anton1ks96/college-app-core/internal/domain"
 )
 
-func (s *AttendanceService) GetAttendanceStreak(login string) (*domain.StreakResponse, error) {
-	startDate := getAcademicYearStart()
-	endDate := getToday()
-
-	req := domain.AttendanceRequest{
-		DStart: startDate,
-		DEnd:   endDate,
+func (s *AttendanceService) GetAttendanceStreak(login string, opts domain.StreakOptions) (*domain.StreakResponse, error) {
+	if opts.Mode == "" {
+		opts.Mode = domain.StreakModeDaily
+	}
+	if opts.Start == "" {
+		yearStart, err := s.calendar.AcademicYearStart(time.Now())
+		if err != nil {
+			return nil, err
+		}
+		opts.Start = yearStart.Format(dateLayout)
+	}
+	if opts.End == "" {
+		opts.End = getToday()
 	}
 
-	records, err := s.portal.FetchAttendance(login, req)
+	records, err := s.GetAttendance(login, opts.Start, opts.End)
 	if err != nil {
 		return nil, fmt.Errorf("failed to fetch attendance for streak: %w", err)
 	}
 
-	return s.calculateStreak(records, startDate, endDate), nil
+	records = s.filterStudyDays(records)
+
+	resp := s.calculateStreak(records, opts)
+	if opts.BySubject {
+		resp.Subjects = s.calculateSubjectStreaks(records, opts)
+	}
+
+	return resp, nil
 }
 
-func (s *AttendanceService) calculateStreak(records []domain.AttendanceRecord, periodStart, periodEnd string) *domain.StreakResponse {
-	if len(records) == 0 {
-		return &domain.StreakResponse{
-			PeriodStart: periodStart,
-			PeriodEnd:   periodEnd,
+func (s *AttendanceService) filterStudyDays(records []domain.AttendanceRecord) []domain.AttendanceRecord {
+	out := make([]domain.AttendanceRecord, 0, len(records))
+	for _, r := range records {
+		if s.calendar.IsStudyDay(r.Day) {
+			out = append(out, r)
 		}
 	}
+	return out
+}
 
-	dayStatus := s.groupByDayAndDetermineStatus(records)
+func (s *AttendanceService) calculateStreak(records []domain.AttendanceRecord, opts domain.StreakOptions) *domain.StreakResponse {
+	resp := &domain.StreakResponse{
+		Mode:        opts.Mode,
+		PeriodStart: opts.Start,
+		PeriodEnd:   opts.End,
+	}
+	if len(records) == 0 {
+		return resp
+	}
+
+	dayStatus := s.determineDayStatus(records, opts)
 	dates := s.getSortedDatesDesc(dayStatus)
 
-	currentStreak := s.calcCurrentStreak(dates, dayStatus)
-	longestStreak := s.calcLongestStreak(dates, dayStatus)
-	totalAttended := s.countAttended(dayStatus)
-	lastAttended := s.findLastAttended(dates, dayStatus)
+	resp.TotalDaysAttended = s.countAttended(dayStatus)
+	resp.TotalSchoolDays = len(dayStatus)
+	resp.LastAttendedDate = s.findLastAttended(dates, dayStatus)
+	if resp.TotalSchoolDays > 0 {
+		resp.AttendanceRate = float64(resp.TotalDaysAttended) / float64(resp.TotalSchoolDays)
+	}
+
+	units, unitStatus := dates, dayStatus
+	if opts.Mode == domain.StreakModeWeekly {
+		unitStatus = s.groupByWeek(dayStatus)
+		units = s.getSortedDatesDesc(unitStatus)
+
+		resp.WeeksAttended = s.countAttended(unitStatus)
+		resp.TotalWeeks = len(unitStatus)
+		resp.LastAttendedWeek = s.findLastAttended(units, unitStatus)
+	}
+
+	resp.CurrentStreak = s.calcCurrentStreak(units, unitStatus)
+	resp.LongestStreak = s.calcLongestStreak(units, unitStatus)
 
-	totalDays := len(dayStatus)
-	var rate float64
-	if totalDays > 0 {
-		rate = float64(totalAttended) / float64(totalDays)
+	return resp
+}
+
+func (s *AttendanceService) calculateSubjectStreaks(records []domain.AttendanceRecord, opts domain.StreakOptions) []domain.SubjectStreak {
+	bySubject := make(map[string][]domain.AttendanceRecord)
+	for _, r := range records {
+		bySubject[r.Title] = append(bySubject[r.Title], r)
 	}
 
-	return &domain.StreakResponse{
-		CurrentStreak:     currentStreak,
-		LongestStreak:     longestStreak,
-		TotalDaysAttended: totalAttended,
-		TotalSchoolDays:   totalDays,
-		AttendanceRate:    rate,
-		LastAttendedDate:  lastAttended,
-		PeriodStart:       periodStart,
-		PeriodEnd:         periodEnd,
+	result := make([]domain.SubjectStreak, 0, len(bySubject))
+	for title, subjectRecords := range bySubject {
+		streak := s.calculateStreak(subjectRecords, opts)
+		result = append(result, domain.SubjectStreak{
+			Title:             title,
+			CurrentStreak:     streak.CurrentStreak,
+			LongestStreak:     streak.LongestStreak,
+			TotalDaysAttended: streak.TotalDaysAttended,
+			TotalSchoolDays:   streak.TotalSchoolDays,
+			AttendanceRate:    streak.AttendanceRate,
+			LastAttendedDate:  streak.LastAttendedDate,
+			WeeksAttended:     streak.WeeksAttended,
+			TotalWeeks:        streak.TotalWeeks,
+			LastAttendedWeek:  streak.LastAttendedWeek,
+		})
 	}
+
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].CurrentStreak != result[j].CurrentStreak {
+			return result[i].CurrentStreak > result[j].CurrentStreak
+		}
+		return result[i].Title < result[j].Title
+	})
+
+	return result
 }
 
-func (s *AttendanceService) groupByDayAndDetermineStatus(records []domain.AttendanceRecord) map[string]bool {
-	dayStatus := make(map[string]bool)
+func (s *AttendanceService) determineDayStatus(records []domain.AttendanceRecord, opts domain.StreakOptions) map[string]bool {
+	type dayMarks struct {
+		attended bool
+		absent   bool
+		excused  bool
+	}
 
+	days := make(map[string]*dayMarks)
 	for _, r := range records {
-		day := r.Day
-		if dayStatus[day] {
+		if !r.StatusName.Marked() {
 			continue
 		}
-		if r.Status == 2 {
+		d, ok := days[r.Day]
+		if !ok {
+			d = &dayMarks{}
+			days[r.Day] = d
+		}
+		switch {
+		case r.StatusName.Attended():
+			d.attended = true
+		case r.StatusName == domain.AttendanceStatusExcused:
+			d.excused = true
+		default:
+			d.absent = true
+		}
+	}
+
+	dayStatus := make(map[string]bool)
+	for day, d := range days {
+		if d.attended {
 			dayStatus[day] = true
-		} else if _, exists := dayStatus[day]; !exists {
-			dayStatus[day] = false
+			continue
+		}
+		if opts.ExcusedKeepsStreak && d.excused && !d.absent {
+			continue
 		}
+		dayStatus[day] = false
 	}
 
 	return dayStatus
 }
 
+func (s *AttendanceService) groupByWeek(dayStatus map[string]bool) map[string]bool {
+	weekStatus := make(map[string]bool)
+	for day, attended := range dayStatus {
+		key := weekKey(day)
+		if current, exists := weekStatus[key]; exists {
+			weekStatus[key] = current && attended
+		} else {
+			weekStatus[key] = attended
+		}
+	}
+
+	return weekStatus
+}
+
+func weekKey(day string) string {
+	t, err := time.ParseInLocation(dateLayout, day, time.Local)
+	if err != nil {
+		return day
+	}
+	year, week := t.ISOWeek()
+	return fmt.Sprintf("%04d-W%02d", year, week)
+}
+
 func (s *AttendanceService) getSortedDatesDesc(dayStatus map[string]bool) []string {
 	dates := make([]string, 0, len(dayStatus))
 	for date := range dayStatus {
@@ -136,17 +241,6 @@ func (s *AttendanceService) findLastAttended(sortedDatesDesc []string, dayStatus
 	return ""
 }
 
-func getAcademicYearStart() string {
-	now := time.Now()
-	year := now.Year()
-
-	if now.Month() < time.September {
-		year--
-	}
-
-	return time.Date(year, time.September, 1, 0, 0, 0, 0, time.Local).Format("2006-01-02")
-}
-
 func getToday() string {
-	return time.Now().Format("2006-01-02")
+	return time.Now().Format(dateLayout)
 }
@@ -0,0 +1,138 @@
+package services
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/anton1ks96/college-app-core/internal/domain"
+)
+
+func streakRecord(day, title string, status domain.AttendanceStatus) domain.AttendanceRecord {
+	return domain.AttendanceRecord{Day: day, Title: title, StatusName: status}
+}
+
+func TestCalculateStreak(t *testing.T) {
+	const (
+		present   = domain.AttendanceStatusPresent
+		late      = domain.AttendanceStatusLate
+		absent    = domain.AttendanceStatusAbsent
+		excused   = domain.AttendanceStatusExcused
+		notMarked = domain.AttendanceStatusNotMarked
+	)
+
+	daily := []domain.AttendanceRecord{
+		streakRecord("2026-09-07", "Math", present),
+		streakRecord("2026-09-08", "Math", absent),
+		streakRecord("2026-09-09", "Math", present),
+		streakRecord("2026-09-10", "Math", late),
+		streakRecord("2026-09-10", "Physics", absent),
+		streakRecord("2026-09-11", "Math", excused),
+		streakRecord("2026-09-12", "Math", notMarked),
+	}
+	weekly := []domain.AttendanceRecord{
+		streakRecord("2026-09-01", "Math", present),
+		streakRecord("2026-09-07", "Math", present),
+		streakRecord("2026-09-08", "Math", absent),
+		streakRecord("2026-09-14", "Math", present),
+		streakRecord("2026-09-16", "Math", present),
+		streakRecord("2026-09-21", "Math", late),
+	}
+
+	tests := []struct {
+		name    string
+		records []domain.AttendanceRecord
+		opts    domain.StreakOptions
+		want    domain.StreakResponse
+	}{
+		{
+			name:    "daily, excused breaks streak",
+			records: daily,
+			opts:    domain.StreakOptions{Mode: domain.StreakModeDaily},
+			want: domain.StreakResponse{
+				Mode: domain.StreakModeDaily, CurrentStreak: 0, LongestStreak: 2,
+				TotalDaysAttended: 3, TotalSchoolDays: 5, AttendanceRate: 0.6, LastAttendedDate: "2026-09-10",
+			},
+		},
+		{
+			name:    "daily, excused keeps streak",
+			records: daily,
+			opts:    domain.StreakOptions{Mode: domain.StreakModeDaily, ExcusedKeepsStreak: true},
+			want: domain.StreakResponse{
+				Mode: domain.StreakModeDaily, CurrentStreak: 2, LongestStreak: 2,
+				TotalDaysAttended: 3, TotalSchoolDays: 4, AttendanceRate: 0.75, LastAttendedDate: "2026-09-10",
+			},
+		},
+		{
+			name:    "weekly",
+			records: weekly,
+			opts:    domain.StreakOptions{Mode: domain.StreakModeWeekly},
+			want: domain.StreakResponse{
+				Mode: domain.StreakModeWeekly, CurrentStreak: 2, LongestStreak: 2,
+				TotalDaysAttended: 5, TotalSchoolDays: 6, AttendanceRate: 5.0 / 6, LastAttendedDate: "2026-09-21",
+				WeeksAttended: 3, TotalWeeks: 4, LastAttendedWeek: "2026-W39",
+			},
+		},
+		{
+			name: "no records",
+			opts: domain.StreakOptions{Mode: domain.StreakModeWeekly},
+			want: domain.StreakResponse{Mode: domain.StreakModeWeekly},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc := &AttendanceService{}
+			got := svc.calculateStreak(tt.records, tt.opts)
+			if !reflect.DeepEqual(*got, tt.want) {
+				t.Errorf("streak = %+v, want %+v", *got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCalculateSubjectStreaks(t *testing.T) {
+	records := []domain.AttendanceRecord{
+		streakRecord("2026-09-07", "Physics", domain.AttendanceStatusPresent),
+		streakRecord("2026-09-08", "Physics", domain.AttendanceStatusAbsent),
+		streakRecord("2026-09-07", "Math", domain.AttendanceStatusPresent),
+		streakRecord("2026-09-08", "Math", domain.AttendanceStatusPresent),
+		streakRecord("2026-09-07", "History", domain.AttendanceStatusPresent),
+	}
+
+	svc := &AttendanceService{}
+	got := svc.calculateSubjectStreaks(records, domain.StreakOptions{Mode: domain.StreakModeDaily})
+
+	want := []struct {
+		title   string
+		current int
+	}{
+		{"Math", 2},
+		{"History", 1},
+		{"Physics", 0},
+	}
+	if len(got) != len(want) {
+		t.Fatalf("got %d subjects, want %d", len(got), len(want))
+	}
+	for i, w := range want {
+		if got[i].Title != w.title || got[i].CurrentStreak != w.current {
+			t.Errorf("subject %d = %s (%d), want %s (%d)", i, got[i].Title, got[i].CurrentStreak, w.title, w.current)
+		}
+	}
+}
+
+func TestWeekKey(t *testing.T) {
+	tests := map[string]string{
+		"2026-09-07": "2026-W37",
+		"2026-09-13": "2026-W37",
+		"2027-01-01": "2026-W53",
+		"2027-01-04": "2027-W01",
+		"2024-12-30": "2025-W01",
+		"not a date": "not a date",
+	}
+
+	for day, want := range tests {
+		if got := weekKey(day); got != want {
+			t.Errorf("weekKey(%q) = %s, want %s", day, got, want)
+		}
+	}
+}
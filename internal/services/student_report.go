@@ -0,0 +1,149 @@
+package services
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/anton1ks96/college-app-core/internal/domain"
+	"github.com/anton1ks96/college-app-core/pkg/pdf"
+)
+
+type StudentReportService struct {
+	performance *PerformanceService
+	attendance  *AttendanceService
+}
+
+func NewStudentReportService(performance *PerformanceService, attendance *AttendanceService) *StudentReportService {
+	return &StudentReportService{
+		performance: performance,
+		attendance:  attendance,
+	}
+}
+
+func (s *StudentReportService) GetStudentReport(login, start, end string) (*domain.StudentReport, error) {
+	var (
+		gradebook     *domain.GradebookResponse
+		summary       *domain.PerformanceSummaryResponse
+		stats         *domain.AttendanceStatsResponse
+		perfErr, aErr error
+	)
+
+	runBounded(2, 2, func(i int) {
+		if i == 0 {
+			gradebook, summary, perfErr = s.performance.GetStudentPerformance(login, start, end)
+		} else {
+			stats, aErr = s.attendance.GetAttendanceStats(login, start, end)
+		}
+	})
+
+	if perfErr != nil {
+		return nil, fmt.Errorf("failed to build performance section: %w", perfErr)
+	}
+	if aErr != nil {
+		return nil, fmt.Errorf("failed to build attendance section: %w", aErr)
+	}
+
+	return &domain.StudentReport{
+		Login:       login,
+		PeriodStart: start,
+		PeriodEnd:   end,
+		GeneratedAt: time.Now(),
+		Summary:     summary,
+		Gradebook:   gradebook,
+		Attendance:  stats,
+	}, nil
+}
+
+func StudentReportRows(report *domain.StudentReport) [][]any {
+	rows := [][]any{
+		{"login", report.Login},
+		{"period_start", report.PeriodStart},
+		{"period_end", report.PeriodEnd},
+		{"average", report.Summary.Average},
+		{"weighted_average", report.Summary.WeightedAverage},
+		{},
+		{"subject", "scores", "numeric_scores", "average", "weighted_average", "error"},
+	}
+	for _, subject := range report.Summary.Subjects {
+		rows = append(rows, []any{subject.Title, subject.Scores, subject.NumericScores, subject.Average, subject.WeightedAverage, subject.Error})
+	}
+
+	rows = append(rows, []any{}, []any{"subject", "work_type", "date", "score", "max_score", "ratio", "description"})
+	for _, subject := range report.Gradebook.Subjects {
+		for _, entry := range subject.Entries {
+			rows = append(rows, []any{subject.Title, entry.WorkType, entry.DateP, entry.Score, entry.MaxScore, entry.Normalized.Ratio, entry.Description})
+		}
+	}
+
+	rows = append(rows, []any{}, []any{"subject", "total", "attended", "missed", "attendance_rate", "hours_missed"})
+	for _, subject := range report.Attendance.Subjects {
+		rows = append(rows, []any{subject.Title, subject.Total, subject.Attended, subject.Missed, subject.AttendanceRate, subject.HoursMissed})
+	}
+
+	return rows
+}
+
+func StudentReportPDF(report *domain.StudentReport) (*pdf.Document, error) {
+	doc, err := pdf.New()
+	if err != nil {
+		return nil, err
+	}
+
+	doc.Heading("Отчёт студента " + report.Login)
+	doc.Paragraph(fmt.Sprintf("Период: %s — %s", report.PeriodStart, report.PeriodEnd))
+	doc.Paragraph("Сформирован: " + report.GeneratedAt.Format("2006-01-02 15:04"))
+
+	doc.Heading("Средние баллы")
+	doc.Paragraph(fmt.Sprintf("Средний балл: %s, взвешенный: %s",
+		formatRatio(report.Summary.Average), formatRatio(report.Summary.WeightedAverage)))
+
+	summaryWidths := []float64{235, 60, 80, 70, 70}
+	doc.Row([]string{"Предмет", "Оценок", "Числовых", "Средний", "Взвеш."}, summaryWidths)
+	for _, subject := range report.Summary.Subjects {
+		if subject.Error != "" {
+			doc.Row([]string{subject.Title, "ошибка: " + subject.Error}, []float64{235})
+			continue
+		}
+		doc.Row([]string{
+			subject.Title,
+			fmt.Sprint(subject.Scores),
+			fmt.Sprint(subject.NumericScores),
+			formatRatio(subject.Average),
+			formatRatio(subject.WeightedAverage),
+		}, summaryWidths)
+	}
+
+	doc.Heading("Журнал оценок")
+	entryWidths := []float64{150, 110, 65, 45, 145}
+	for _, subject := range report.Gradebook.Subjects {
+		if len(subject.Entries) == 0 {
+			continue
+		}
+		doc.Space(4)
+		doc.Paragraph(subject.Title)
+		doc.Row([]string{"Вид работы", "Дата", "Оценка", "Макс.", "Описание"}, entryWidths)
+		for _, entry := range subject.Entries {
+			doc.Row([]string{entry.WorkType, entry.DateP, entry.Score, fmt.Sprint(entry.MaxScore), entry.Description}, entryWidths)
+		}
+	}
+
+	doc.Heading("Посещаемость")
+	attendanceWidths := []float64{215, 60, 70, 60, 55, 55}
+	doc.Row([]string{"Предмет", "Всего", "Посещено", "Пропущено", "Доля", "Часов"}, attendanceWidths)
+	for _, subject := range report.Attendance.Subjects {
+		doc.Row([]string{
+			subject.Title,
+			fmt.Sprint(subject.Total),
+			fmt.Sprint(subject.Attended),
+			fmt.Sprint(subject.Missed),
+			formatRatio(subject.AttendanceRate),
+			fmt.Sprintf("%.2f", subject.HoursMissed),
+		}, attendanceWidths)
+	}
+
+	return doc, nil
+}
+
+func formatRatio(value float64) string {
+	return fmt.Sprintf("%.2f", value)
+}
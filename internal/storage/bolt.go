@@ -0,0 +1,609 @@
+package storage
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/anton1ks96/college-app-core/internal/domain"
+	bolt "go.etcd.io/bbolt"
+)
+
+const (
+	snapshotDayLayout        = "2006-01-02"
+	maxSnapshotDays          = 366
+	defaultSnapshotRetention = 90 * 24 * time.Hour
+)
+
+type BoltStorage struct {
+	db                *bolt.DB
+	snapshotRetention time.Duration
+}
+
+type scheduleDay struct {
+	Events  []domain.ScheduleEvent `json:"events"`
+	SavedAt time.Time              `json:"saved_at"`
+}
+
+func NewBoltStorage(path string, timeout, snapshotRetention time.Duration) (*BoltStorage, error) {
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return nil, fmt.Errorf("failed to create storage directory: %w", err)
+	}
+
+	db, err := bolt.Open(path, 0o600, &bolt.Options{Timeout: timeout})
+	if err != nil {
+		return nil, fmt.Errorf("failed to open storage: %w", err)
+	}
+
+	if err := migrate(db); err != nil {
+		db.Close()
+		return nil, fmt.Errorf("failed to migrate storage: %w", err)
+	}
+
+	if snapshotRetention <= 0 {
+		snapshotRetention = defaultSnapshotRetention
+	}
+
+	return &BoltStorage{db: db, snapshotRetention: snapshotRetention}, nil
+}
+
+func (s *BoltStorage) Close() error {
+	return s.db.Close()
+}
+
+func (s *BoltStorage) Subscribe(login string) error {
+	return s.db.Update(func(tx *bolt.Tx) error {
+		return tx.Bucket(subscriptionsBucket).Put([]byte(login), []byte(time.Now().Format(time.RFC3339)))
+	})
+}
+
+func (s *BoltStorage) Unsubscribe(login string) error {
+	return s.db.Update(func(tx *bolt.Tx) error {
+		if err := tx.Bucket(subscriptionsBucket).Delete([]byte(login)); err != nil {
+			return err
+		}
+		return tx.Bucket(seenScoresBucket).Delete([]byte(login))
+	})
+}
+
+func (s *BoltStorage) IsSubscribed(login string) (bool, error) {
+	subscribed := false
+	err := s.db.View(func(tx *bolt.Tx) error {
+		subscribed = tx.Bucket(subscriptionsBucket).Get([]byte(login)) != nil
+		return nil
+	})
+	return subscribed, err
+}
+
+func (s *BoltStorage) Subscribers() ([]string, error) {
+	logins := make([]string, 0)
+	err := s.db.View(func(tx *bolt.Tx) error {
+		return tx.Bucket(subscriptionsBucket).ForEach(func(k, _ []byte) error {
+			logins = append(logins, string(k))
+			return nil
+		})
+	})
+	return logins, err
+}
+
+func (s *BoltStorage) SeenScores(login string) (map[string]string, bool, error) {
+	var seen map[string]string
+	found := false
+
+	err := s.db.View(func(tx *bolt.Tx) error {
+		raw := tx.Bucket(seenScoresBucket).Get([]byte(login))
+		if raw == nil {
+			return nil
+		}
+		found = true
+		return json.Unmarshal(raw, &seen)
+	})
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to load seen scores: %w", err)
+	}
+
+	return seen, found, nil
+}
+
+func (s *BoltStorage) SaveSeenScores(login string, seen map[string]string) error {
+	return s.put(seenScoresBucket, login, seen)
+}
+
+func (s *BoltStorage) SaveScheduleSnapshot(snapshot domain.ScheduleSnapshot) error {
+	days, err := snapshotDays(snapshot.Start, snapshot.End)
+	if err != nil {
+		return err
+	}
+
+	byDay := make(map[string][]domain.ScheduleEvent, len(days))
+	for _, ev := range snapshot.Events {
+		byDay[ev.Day] = append(byDay[ev.Day], ev)
+	}
+
+	err = s.db.Update(func(tx *bolt.Tx) error {
+		snapshots := tx.Bucket(scheduleSnapshotsBucket)
+		group, err := snapshots.CreateBucketIfNotExists([]byte(strings.ToLower(snapshot.Group)))
+		if err != nil {
+			return fmt.Errorf("failed to open snapshots for %s: %w", snapshot.Group, err)
+		}
+
+		for _, day := range days {
+			events := byDay[day]
+			if events == nil {
+				events = make([]domain.ScheduleEvent, 0)
+			}
+			raw, err := json.Marshal(scheduleDay{Events: events, SavedAt: snapshot.SavedAt})
+			if err != nil {
+				return err
+			}
+			if err := group.Put([]byte(day), raw); err != nil {
+				return err
+			}
+		}
+
+		return pruneSnapshots(snapshots, snapshot.SavedAt.Add(-s.snapshotRetention))
+	})
+	if err != nil {
+		return fmt.Errorf("failed to save schedule snapshot: %w", err)
+	}
+	return nil
+}
+
+func (s *BoltStorage) ScheduleSnapshot(group, start, end string) (*domain.ScheduleSnapshot, bool, error) {
+	days, err := snapshotDays(start, end)
+	if err != nil {
+		return nil, false, err
+	}
+
+	snapshot := &domain.ScheduleSnapshot{
+		Group:  group,
+		Start:  start,
+		End:    end,
+		Events: make([]domain.ScheduleEvent, 0),
+	}
+	found := true
+
+	err = s.db.View(func(tx *bolt.Tx) error {
+		bucket := tx.Bucket(scheduleSnapshotsBucket).Bucket([]byte(strings.ToLower(group)))
+		if bucket == nil {
+			found = false
+			return nil
+		}
+
+		for _, day := range days {
+			raw := bucket.Get([]byte(day))
+			if raw == nil {
+				found = false
+				return nil
+			}
+
+			var stored scheduleDay
+			if err := json.Unmarshal(raw, &stored); err != nil {
+				return err
+			}
+			snapshot.Events = append(snapshot.Events, stored.Events...)
+			if snapshot.SavedAt.IsZero() || stored.SavedAt.Before(snapshot.SavedAt) {
+				snapshot.SavedAt = stored.SavedAt
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to load schedule snapshot: %w", err)
+	}
+	if !found {
+		return nil, false, nil
+	}
+
+	return snapshot, true, nil
+}
+
+func pruneSnapshots(snapshots *bolt.Bucket, cutoff time.Time) error {
+	before := []byte(cutoff.Format(snapshotDayLayout))
+
+	return snapshots.ForEachBucket(func(name []byte) error {
+		group := snapshots.Bucket(name)
+		c := group.Cursor()
+		for k, _ := c.First(); k != nil && bytes.Compare(k, before) < 0; k, _ = c.First() {
+			if err := c.Delete(); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+func snapshotDays(start, end string) ([]string, error) {
+	from, err := time.Parse(snapshotDayLayout, start)
+	if err != nil {
+		return nil, fmt.Errorf("invalid snapshot start %q: %w", start, err)
+	}
+	to, err := time.Parse(snapshotDayLayout, end)
+	if err != nil {
+		return nil, fmt.Errorf("invalid snapshot end %q: %w", end, err)
+	}
+	if to.Before(from) || to.Sub(from) > maxSnapshotDays*24*time.Hour {
+		return nil, fmt.Errorf("invalid snapshot range %s..%s", start, end)
+	}
+
+	days := make([]string, 0)
+	for day := from; !day.After(to); day = day.AddDate(0, 0, 1) {
+		days = append(days, day.Format(snapshotDayLayout))
+	}
+	return days, nil
+}
+
+func (s *BoltStorage) RecordAttendance(login string, records []domain.AttendanceRecord, observedAt time.Time) error {
+	return s.db.Update(func(tx *bolt.Tx) error {
+		state, history, err := userBuckets(tx, attendanceStateBucket, attendanceHistoryBucket, login)
+		if err != nil {
+			return err
+		}
+
+		for _, r := range records {
+			key := []byte(strings.Join([]string{strconv.Itoa(r.ClID), r.Day, r.Start}, "|"))
+			status := []byte(r.StatusName)
+
+			prev := state.Get(key)
+			if prev != nil && string(prev) != string(status) {
+				change := domain.AttendanceChange{
+					ClID:           r.ClID,
+					Day:            r.Day,
+					Start:          r.Start,
+					Title:          r.Title,
+					Status:         r.StatusName,
+					PreviousStatus: domain.AttendanceStatus(prev),
+					ObservedAt:     observedAt,
+				}
+				if err := appendHistory(history, change); err != nil {
+					return err
+				}
+			}
+
+			if err := state.Put(key, status); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+}
+
+func (s *BoltStorage) AttendanceHistory(login string, from, to time.Time) ([]domain.AttendanceChange, error) {
+	changes := make([]domain.AttendanceChange, 0)
+	err := s.db.View(func(tx *bolt.Tx) error {
+		history := tx.Bucket(attendanceHistoryBucket).Bucket([]byte(login))
+		if history == nil {
+			return nil
+		}
+		return history.ForEach(func(_, v []byte) error {
+			var change domain.AttendanceChange
+			if err := json.Unmarshal(v, &change); err != nil {
+				return err
+			}
+			if inWindow(change.ObservedAt, from, to) {
+				changes = append(changes, change)
+			}
+			return nil
+		})
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to load attendance history: %w", err)
+	}
+
+	return changes, nil
+}
+
+func (s *BoltStorage) RecordScores(login, suID string, entries []domain.GradebookEntry, observedAt time.Time) error {
+	return s.db.Update(func(tx *bolt.Tx) error {
+		state, history, err := userBuckets(tx, scoreStateBucket, scoreHistoryBucket, login)
+		if err != nil {
+			return err
+		}
+
+		prefix := []byte(suID + "|")
+		first, _ := state.Cursor().Seek(prefix)
+		known := first != nil && bytes.HasPrefix(first, prefix)
+		for _, entry := range entries {
+			key := []byte(strings.Join([]string{suID, entry.Subject, entry.WorkType, entry.DateF, entry.DateP, entry.Description}, "|"))
+			value := entry.Score + "|" + strconv.Itoa(entry.MaxScore)
+
+			prev := state.Get(key)
+			if known && string(prev) != value {
+				change := domain.ScoreChange{
+					SuID:        suID,
+					Subject:     entry.Subject,
+					WorkType:    entry.WorkType,
+					DateF:       entry.DateF,
+					DateP:       entry.DateP,
+					Description: entry.Description,
+					Score:       entry.Score,
+					MaxScore:    entry.MaxScore,
+					ObservedAt:  observedAt,
+				}
+				if prev != nil {
+					change.PreviousScore = strings.SplitN(string(prev), "|", 2)[0]
+				}
+				if err := appendHistory(history, change); err != nil {
+					return err
+				}
+			}
+
+			if err := state.Put(key, []byte(value)); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+}
+
+func (s *BoltStorage) ScoreHistory(login string, from, to time.Time) ([]domain.ScoreChange, error) {
+	changes := make([]domain.ScoreChange, 0)
+	err := s.db.View(func(tx *bolt.Tx) error {
+		history := tx.Bucket(scoreHistoryBucket).Bucket([]byte(login))
+		if history == nil {
+			return nil
+		}
+		return history.ForEach(func(_, v []byte) error {
+			var change domain.ScoreChange
+			if err := json.Unmarshal(v, &change); err != nil {
+				return err
+			}
+			if inWindow(change.ObservedAt, from, to) {
+				changes = append(changes, change)
+			}
+			return nil
+		})
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to load score history: %w", err)
+	}
+
+	return changes, nil
+}
+
+func (s *BoltStorage) Preferences(login string) (*domain.UserPreferences, bool, error) {
+	var prefs domain.UserPreferences
+	found, err := s.get(preferencesBucket, login, &prefs)
+	if err != nil || !found {
+		return nil, false, err
+	}
+	return &prefs, true, nil
+}
+
+func (s *BoltStorage) SavePreferences(login string, prefs domain.UserPreferences) error {
+	return s.put(preferencesBucket, login, prefs)
+}
+
+func (s *BoltStorage) Customization(login string) (*domain.ScheduleCustomization, bool, error) {
+	var customization domain.ScheduleCustomization
+	found, err := s.get(customizationsBucket, login, &customization)
+	if err != nil || !found {
+		return nil, false, err
+	}
+	return &customization, true, nil
+}
+
+func (s *BoltStorage) SaveCustomization(login string, customization domain.ScheduleCustomization) error {
+	return s.put(customizationsBucket, login, customization)
+}
+
+func (s *BoltStorage) Notes(login string) ([]domain.LessonNote, error) {
+	notes := make([]domain.LessonNote, 0)
+	err := s.listUserItems(notesBucket, login, func(raw []byte) error {
+		var note domain.LessonNote
+		if err := json.Unmarshal(raw, &note); err != nil {
+			return err
+		}
+		notes = append(notes, note)
+		return nil
+	})
+	return notes, err
+}
+
+func (s *BoltStorage) Note(login, id string) (*domain.LessonNote, bool, error) {
+	var note domain.LessonNote
+	found, err := s.getUserItem(notesBucket, login, id, &note)
+	if err != nil || !found {
+		return nil, false, err
+	}
+	return &note, true, nil
+}
+
+func (s *BoltStorage) SaveNote(login string, note domain.LessonNote) (*domain.LessonNote, error) {
+	err := s.putUserItem(notesBucket, login, &note.ID, func() ([]byte, error) {
+		return json.Marshal(note)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &note, nil
+}
+
+func (s *BoltStorage) DeleteNote(login, id string) (bool, error) {
+	return s.deleteUserItem(notesBucket, login, id)
+}
+
+func (s *BoltStorage) PersonalEvents(login string) ([]domain.PersonalEvent, error) {
+	events := make([]domain.PersonalEvent, 0)
+	err := s.listUserItems(personalEventsBucket, login, func(raw []byte) error {
+		var event domain.PersonalEvent
+		if err := json.Unmarshal(raw, &event); err != nil {
+			return err
+		}
+		events = append(events, event)
+		return nil
+	})
+	return events, err
+}
+
+func (s *BoltStorage) PersonalEvent(login, id string) (*domain.PersonalEvent, bool, error) {
+	var event domain.PersonalEvent
+	found, err := s.getUserItem(personalEventsBucket, login, id, &event)
+	if err != nil || !found {
+		return nil, false, err
+	}
+	return &event, true, nil
+}
+
+func (s *BoltStorage) SavePersonalEvent(login string, event domain.PersonalEvent) (*domain.PersonalEvent, error) {
+	err := s.putUserItem(personalEventsBucket, login, &event.ID, func() ([]byte, error) {
+		return json.Marshal(event)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &event, nil
+}
+
+func (s *BoltStorage) DeletePersonalEvent(login, id string) (bool, error) {
+	return s.deleteUserItem(personalEventsBucket, login, id)
+}
+
+func (s *BoltStorage) listUserItems(bucket []byte, login string, fn func(raw []byte) error) error {
+	err := s.db.View(func(tx *bolt.Tx) error {
+		items := tx.Bucket(bucket).Bucket([]byte(login))
+		if items == nil {
+			return nil
+		}
+		return items.ForEach(func(_, v []byte) error {
+			return fn(v)
+		})
+	})
+	if err != nil {
+		return fmt.Errorf("failed to load %s: %w", bucket, err)
+	}
+	return nil
+}
+
+func (s *BoltStorage) getUserItem(bucket []byte, login, id string, value any) (bool, error) {
+	found := false
+	err := s.db.View(func(tx *bolt.Tx) error {
+		items := tx.Bucket(bucket).Bucket([]byte(login))
+		if items == nil {
+			return nil
+		}
+		raw := items.Get([]byte(id))
+		if raw == nil {
+			return nil
+		}
+		found = true
+		return json.Unmarshal(raw, value)
+	})
+	if err != nil {
+		return false, fmt.Errorf("failed to load %s: %w", bucket, err)
+	}
+	return found, nil
+}
+
+func (s *BoltStorage) putUserItem(bucket []byte, login string, id *string, encode func() ([]byte, error)) error {
+	err := s.db.Update(func(tx *bolt.Tx) error {
+		items, err := tx.Bucket(bucket).CreateBucketIfNotExists([]byte(login))
+		if err != nil {
+			return err
+		}
+
+		if *id == "" {
+			seq, err := items.NextSequence()
+			if err != nil {
+				return err
+			}
+			*id = strconv.FormatUint(seq, 10)
+		}
+
+		raw, err := encode()
+		if err != nil {
+			return err
+		}
+		return items.Put([]byte(*id), raw)
+	})
+	if err != nil {
+		return fmt.Errorf("failed to save %s: %w", bucket, err)
+	}
+	return nil
+}
+
+func (s *BoltStorage) deleteUserItem(bucket []byte, login, id string) (bool, error) {
+	found := false
+	err := s.db.Update(func(tx *bolt.Tx) error {
+		items := tx.Bucket(bucket).Bucket([]byte(login))
+		if items == nil || items.Get([]byte(id)) == nil {
+			return nil
+		}
+		found = true
+		return items.Delete([]byte(id))
+	})
+	if err != nil {
+		return false, fmt.Errorf("failed to delete %s: %w", bucket, err)
+	}
+	return found, nil
+}
+
+func (s *BoltStorage) put(bucket []byte, key string, value any) error {
+	raw, err := json.Marshal(value)
+	if err != nil {
+		return fmt.Errorf("failed to encode %s: %w", bucket, err)
+	}
+
+	return s.db.Update(func(tx *bolt.Tx) error {
+		return tx.Bucket(bucket).Put([]byte(key), raw)
+	})
+}
+
+func (s *BoltStorage) get(bucket []byte, key string, value any) (bool, error) {
+	found := false
+	err := s.db.View(func(tx *bolt.Tx) error {
+		raw := tx.Bucket(bucket).Get([]byte(key))
+		if raw == nil {
+			return nil
+		}
+		found = true
+		return json.Unmarshal(raw, value)
+	})
+	if err != nil {
+		return false, fmt.Errorf("failed to load %s: %w", bucket, err)
+	}
+	return found, nil
+}
+
+func userBuckets(tx *bolt.Tx, stateName, historyName []byte, login string) (*bolt.Bucket, *bolt.Bucket, error) {
+	state, err := tx.Bucket(stateName).CreateBucketIfNotExists([]byte(login))
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to open %s for %s: %w", stateName, login, err)
+	}
+	history, err := tx.Bucket(historyName).CreateBucketIfNotExists([]byte(login))
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to open %s for %s: %w", historyName, login, err)
+	}
+	return state, history, nil
+}
+
+func appendHistory(history *bolt.Bucket, change any) error {
+	seq, err := history.NextSequence()
+	if err != nil {
+		return err
+	}
+
+	raw, err := json.Marshal(change)
+	if err != nil {
+		return err
+	}
+
+	return history.Put(itob(seq), raw)
+}
+
+func inWindow(at, from, to time.Time) bool {
+	if !from.IsZero() && at.Before(from) {
+		return false
+	}
+	if !to.IsZero() && !at.Before(to) {
+		return false
+	}
+	return true
+}
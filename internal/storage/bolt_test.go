@@ -0,0 +1,210 @@
+package storage
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/anton1ks96/college-app-core/internal/domain"
+	bolt "go.etcd.io/bbolt"
+)
+
+func newTestStorage(t *testing.T, retention time.Duration) *BoltStorage {
+	t.Helper()
+
+	store, err := NewBoltStorage(filepath.Join(t.TempDir(), "test.db"), time.Second, retention)
+	if err != nil {
+		t.Fatalf("failed to open storage: %v", err)
+	}
+	t.Cleanup(func() { store.Close() })
+	return store
+}
+
+func TestScheduleSnapshotCoveringRange(t *testing.T) {
+	store := newTestStorage(t, 0)
+	savedAt := time.Now()
+
+	err := store.SaveScheduleSnapshot(domain.ScheduleSnapshot{
+		Group: "ИСП-21",
+		Start: "2026-09-07",
+		End:   "2026-09-13",
+		Events: []domain.ScheduleEvent{
+			{ClID: "1", Day: "2026-09-07", Start: "09:00"},
+			{ClID: "2", Day: "2026-09-09", Start: "09:00"},
+			{ClID: "3", Day: "2026-09-09", Start: "10:40"},
+		},
+		SavedAt: savedAt,
+	})
+	if err != nil {
+		t.Fatalf("SaveScheduleSnapshot: %v", err)
+	}
+
+	tests := []struct {
+		name   string
+		group  string
+		start  string
+		end    string
+		found  bool
+		events []string
+	}{
+		{name: "exact range", group: "ИСП-21", start: "2026-09-07", end: "2026-09-13", found: true, events: []string{"1", "2", "3"}},
+		{name: "covered sub-range", group: "ИСП-21", start: "2026-09-09", end: "2026-09-09", found: true, events: []string{"2", "3"}},
+		{name: "covered empty day", group: "ИСП-21", start: "2026-09-08", end: "2026-09-08", found: true, events: []string{}},
+		{name: "group is case-insensitive", group: "исп-21", start: "2026-09-07", end: "2026-09-07", found: true, events: []string{"1"}},
+		{name: "partially covered range", group: "ИСП-21", start: "2026-09-12", end: "2026-09-14", found: false},
+		{name: "unknown group", group: "ИСП-22", start: "2026-09-07", end: "2026-09-07", found: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			snapshot, found, err := store.ScheduleSnapshot(tt.group, tt.start, tt.end)
+			if err != nil {
+				t.Fatalf("ScheduleSnapshot: %v", err)
+			}
+			if found != tt.found {
+				t.Fatalf("found = %v, want %v", found, tt.found)
+			}
+			if !found {
+				return
+			}
+			if len(snapshot.Events) != len(tt.events) {
+				t.Fatalf("got %d events, want %d", len(snapshot.Events), len(tt.events))
+			}
+			for i, clID := range tt.events {
+				if snapshot.Events[i].ClID != clID {
+					t.Errorf("event %d = %s, want %s", i, snapshot.Events[i].ClID, clID)
+				}
+			}
+			if !snapshot.SavedAt.Equal(savedAt) {
+				t.Errorf("SavedAt = %v, want %v", snapshot.SavedAt, savedAt)
+			}
+		})
+	}
+}
+
+func TestScheduleSnapshotOverwritesDays(t *testing.T) {
+	store := newTestStorage(t, 0)
+	first := time.Now().Add(-time.Hour)
+	second := time.Now()
+
+	save := func(start, end string, savedAt time.Time, events ...domain.ScheduleEvent) {
+		t.Helper()
+		err := store.SaveScheduleSnapshot(domain.ScheduleSnapshot{Group: "G", Start: start, End: end, Events: events, SavedAt: savedAt})
+		if err != nil {
+			t.Fatalf("SaveScheduleSnapshot: %v", err)
+		}
+	}
+
+	save("2026-09-07", "2026-09-08", first, domain.ScheduleEvent{ClID: "old", Day: "2026-09-08"})
+	save("2026-09-08", "2026-09-09", second, domain.ScheduleEvent{ClID: "new", Day: "2026-09-08"})
+
+	snapshot, found, err := store.ScheduleSnapshot("G", "2026-09-07", "2026-09-09")
+	if err != nil || !found {
+		t.Fatalf("ScheduleSnapshot: found=%v err=%v", found, err)
+	}
+	if len(snapshot.Events) != 1 || snapshot.Events[0].ClID != "new" {
+		t.Fatalf("got events %+v, want only the newer one", snapshot.Events)
+	}
+	if !snapshot.SavedAt.Equal(first) {
+		t.Errorf("SavedAt = %v, want oldest day %v", snapshot.SavedAt, first)
+	}
+}
+
+func TestScheduleSnapshotPrunesOldDays(t *testing.T) {
+	store := newTestStorage(t, 30*24*time.Hour)
+	now := time.Date(2026, time.October, 1, 12, 0, 0, 0, time.UTC)
+
+	save := func(group, start, end string, savedAt time.Time) {
+		t.Helper()
+		err := store.SaveScheduleSnapshot(domain.ScheduleSnapshot{Group: group, Start: start, End: end, SavedAt: savedAt})
+		if err != nil {
+			t.Fatalf("SaveScheduleSnapshot: %v", err)
+		}
+	}
+
+	save("A", "2026-08-01", "2026-08-31", now.AddDate(0, -2, 0))
+	save("B", "2026-09-01", "2026-10-07", now)
+
+	if _, found, _ := store.ScheduleSnapshot("A", "2026-08-01", "2026-08-31"); found {
+		t.Error("days older than the retention window were kept")
+	}
+	if _, found, _ := store.ScheduleSnapshot("B", "2026-09-01", "2026-10-07"); !found {
+		t.Error("days inside the retention window were pruned")
+	}
+
+	count := 0
+	err := store.db.View(func(tx *bolt.Tx) error {
+		return tx.Bucket(scheduleSnapshotsBucket).ForEachBucket(func(name []byte) error {
+			return tx.Bucket(scheduleSnapshotsBucket).Bucket(name).ForEach(func(_, _ []byte) error {
+				count++
+				return nil
+			})
+		})
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if count != 37 {
+		t.Errorf("stored %d snapshot days, want 37", count)
+	}
+}
+
+func TestScheduleSnapshotRejectsInvalidRange(t *testing.T) {
+	store := newTestStorage(t, 0)
+
+	tests := []struct {
+		name  string
+		start string
+		end   string
+	}{
+		{name: "malformed start", start: "07.09.2026", end: "2026-09-07"},
+		{name: "end before start", start: "2026-09-08", end: "2026-09-07"},
+		{name: "too long", start: "2026-01-01", end: "2027-06-01"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := store.SaveScheduleSnapshot(domain.ScheduleSnapshot{Group: "G", Start: tt.start, End: tt.end})
+			if err == nil {
+				t.Fatal("SaveScheduleSnapshot accepted an invalid range")
+			}
+			if _, _, err := store.ScheduleSnapshot("G", tt.start, tt.end); err == nil {
+				t.Fatal("ScheduleSnapshot accepted an invalid range")
+			}
+		})
+	}
+}
+
+func TestRecordScoresBaselinePerSubject(t *testing.T) {
+	store := newTestStorage(t, 0)
+	now := time.Now()
+	entry := func(subject, score string) []domain.GradebookEntry {
+		return []domain.GradebookEntry{{Subject: subject, WorkType: "test", DateF: "2026-09-07", Score: score, MaxScore: 5}}
+	}
+
+	steps := []struct {
+		suID    string
+		entries []domain.GradebookEntry
+	}{
+		{suID: "1", entries: entry("Math", "4")},
+		{suID: "2", entries: entry("Physics", "3")},
+		{suID: "2", entries: entry("Physics", "5")},
+		{suID: "1", entries: entry("Math", "4")},
+	}
+	for _, step := range steps {
+		if err := store.RecordScores("student", step.suID, step.entries, now); err != nil {
+			t.Fatalf("RecordScores: %v", err)
+		}
+	}
+
+	changes, err := store.ScoreHistory("student", time.Time{}, time.Time{})
+	if err != nil {
+		t.Fatalf("ScoreHistory: %v", err)
+	}
+	if len(changes) != 1 {
+		t.Fatalf("got %d changes, want 1: %+v", len(changes), changes)
+	}
+	if changes[0].SuID != "2" || changes[0].PreviousScore != "3" || changes[0].Score != "5" {
+		t.Errorf("unexpected change %+v", changes[0])
+	}
+}
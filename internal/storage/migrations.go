@@ -0,0 +1,129 @@
+package storage
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+
+	bolt "go.etcd.io/bbolt"
+	bolterrors "go.etcd.io/bbolt/errors"
+)
+
+var (
+	metaBucket              = []byte("meta")
+	subscriptionsBucket     = []byte("subscriptions")
+	seenScoresBucket        = []byte("seen_scores")
+	scheduleSnapshotsBucket = []byte("schedule_snapshots")
+	attendanceStateBucket   = []byte("attendance_state")
+	attendanceHistoryBucket = []byte("attendance_history")
+	scoreStateBucket        = []byte("score_state")
+	scoreHistoryBucket      = []byte("score_history")
+	preferencesBucket       = []byte("preferences")
+	customizationsBucket    = []byte("customizations")
+	notesBucket             = []byte("notes")
+	personalEventsBucket    = []byte("personal_events")
+
+	schemaVersionKey = []byte("schema_version")
+)
+
+type migration struct {
+	version int
+	name    string
+	up      func(tx *bolt.Tx) error
+}
+
+var migrations = []migration{
+	{
+		version: 1,
+		name:    "create notification and snapshot buckets",
+		up: createBuckets(
+			subscriptionsBucket,
+			seenScoresBucket,
+			scheduleSnapshotsBucket,
+		),
+	},
+	{
+		version: 2,
+		name:    "create history buckets",
+		up: createBuckets(
+			attendanceStateBucket,
+			attendanceHistoryBucket,
+			scoreStateBucket,
+			scoreHistoryBucket,
+		),
+	},
+	{
+		version: 3,
+		name:    "create preferences bucket",
+		up:      createBuckets(preferencesBucket),
+	},
+	{
+		version: 4,
+		name:    "create customizations bucket",
+		up:      createBuckets(customizationsBucket),
+	},
+	{
+		version: 5,
+		name:    "create notes and personal events buckets",
+		up:      createBuckets(notesBucket, personalEventsBucket),
+	},
+	{
+		version: 6,
+		name:    "store schedule snapshots per group and day",
+		up:      recreateBuckets(scheduleSnapshotsBucket),
+	},
+}
+
+func createBuckets(names ...[]byte) func(tx *bolt.Tx) error {
+	return func(tx *bolt.Tx) error {
+		for _, name := range names {
+			if _, err := tx.CreateBucketIfNotExists(name); err != nil {
+				return fmt.Errorf("failed to create bucket %s: %w", name, err)
+			}
+		}
+		return nil
+	}
+}
+
+func recreateBuckets(names ...[]byte) func(tx *bolt.Tx) error {
+	return func(tx *bolt.Tx) error {
+		for _, name := range names {
+			if err := tx.DeleteBucket(name); err != nil && !errors.Is(err, bolterrors.ErrBucketNotFound) {
+				return fmt.Errorf("failed to delete bucket %s: %w", name, err)
+			}
+		}
+		return createBuckets(names...)(tx)
+	}
+}
+
+func migrate(db *bolt.DB) error {
+	return db.Update(func(tx *bolt.Tx) error {
+		meta, err := tx.CreateBucketIfNotExists(metaBucket)
+		if err != nil {
+			return fmt.Errorf("failed to create meta bucket: %w", err)
+		}
+
+		current := 0
+		if raw := meta.Get(schemaVersionKey); len(raw) == 8 {
+			current = int(binary.BigEndian.Uint64(raw))
+		}
+
+		for _, m := range migrations {
+			if m.version <= current {
+				continue
+			}
+			if err := m.up(tx); err != nil {
+				return fmt.Errorf("migration %d (%s) failed: %w", m.version, m.name, err)
+			}
+			current = m.version
+		}
+
+		return meta.Put(schemaVersionKey, itob(uint64(current)))
+	})
+}
+
+func itob(v uint64) []byte {
+	b := make([]byte, 8)
+	binary.BigEndian.PutUint64(b, v)
+	return b
+}
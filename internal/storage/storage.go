@@ -0,0 +1,39 @@
+package storage
+
+import (
+	"time"
+
+	"github.com/anton1ks96/college-app-core/internal/domain"
+	"github.com/anton1ks96/college-app-core/internal/repository"
+)
+
+type Storage interface {
+	repository.NotificationRepository
+
+	SaveScheduleSnapshot(snapshot domain.ScheduleSnapshot) error
+	ScheduleSnapshot(group, start, end string) (*domain.ScheduleSnapshot, bool, error)
+
+	RecordAttendance(login string, records []domain.AttendanceRecord, observedAt time.Time) error
+	AttendanceHistory(login string, from, to time.Time) ([]domain.AttendanceChange, error)
+
+	RecordScores(login, suID string, entries []domain.GradebookEntry, observedAt time.Time) error
+	ScoreHistory(login string, from, to time.Time) ([]domain.ScoreChange, error)
+
+	Preferences(login string) (*domain.UserPreferences, bool, error)
+	SavePreferences(login string, prefs domain.UserPreferences) error
+
+	Customization(login string) (*domain.ScheduleCustomization, bool, error)
+	SaveCustomization(login string, customization domain.ScheduleCustomization) error
+
+	Notes(login string) ([]domain.LessonNote, error)
+	Note(login, id string) (*domain.LessonNote, bool, error)
+	SaveNote(login string, note domain.LessonNote) (*domain.LessonNote, error)
+	DeleteNote(login, id string) (bool, error)
+
+	PersonalEvents(login string) ([]domain.PersonalEvent, error)
+	PersonalEvent(login, id string) (*domain.PersonalEvent, bool, error)
+	SavePersonalEvent(login string, event domain.PersonalEvent) (*domain.PersonalEvent, error)
+	DeletePersonalEvent(login, id string) (bool, error)
+
+	Close() error
+}
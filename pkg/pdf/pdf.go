@@ -0,0 +1,325 @@
+package pdf
+
+import (
+	"bytes"
+	"compress/zlib"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	"golang.org/x/image/font"
+	"golang.org/x/image/font/gofont/goregular"
+	"golang.org/x/image/font/sfnt"
+	"golang.org/x/image/math/fixed"
+)
+
+const (
+	pageWidth  = 595.28
+	pageHeight = 841.89
+	margin     = 40.0
+	lineFactor = 1.4
+)
+
+type Document struct {
+	font       *sfnt.Font
+	buf        sfnt.Buffer
+	unitsPerEm float64
+	glyphs     map[sfnt.GlyphIndex]rune
+	widths     map[sfnt.GlyphIndex]float64
+	pages      []*bytes.Buffer
+	y          float64
+}
+
+func New() (*Document, error) {
+	f, err := sfnt.Parse(goregular.TTF)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse font: %w", err)
+	}
+
+	d := &Document{
+		font:       f,
+		unitsPerEm: float64(f.UnitsPerEm()),
+		glyphs:     make(map[sfnt.GlyphIndex]rune),
+		widths:     make(map[sfnt.GlyphIndex]float64),
+	}
+	d.newPage()
+
+	return d, nil
+}
+
+func (d *Document) Heading(text string) {
+	d.Space(6)
+	d.line(text, 14, margin)
+	d.Space(2)
+}
+
+func (d *Document) Paragraph(text string) {
+	const size = 10
+	for _, line := range d.wrap(text, size, pageWidth-2*margin) {
+		d.line(line, size, margin)
+	}
+}
+
+func (d *Document) Row(cells []string, widths []float64) {
+	const size = 9
+	d.ensureSpace(size * lineFactor)
+	d.y -= size * lineFactor
+
+	x := margin
+	for i, cell := range cells {
+		width := pageWidth - margin - x
+		if i < len(widths) {
+			width = widths[i]
+		}
+		d.text(d.truncate(cell, size, width-4), size, x, d.y)
+		x += width
+	}
+}
+
+func (d *Document) Space(height float64) {
+	d.y -= height
+	if d.y < margin {
+		d.newPage()
+	}
+}
+
+func (d *Document) Write(w io.Writer) error {
+	fontFile, err := compress(goregular.TTF)
+	if err != nil {
+		return err
+	}
+	toUnicode, err := compress([]byte(d.toUnicodeCMap()))
+	if err != nil {
+		return err
+	}
+
+	ascent, descent, bbox, err := d.metrics()
+	if err != nil {
+		return err
+	}
+
+	var out bytes.Buffer
+	offsets := make([]int, 0)
+	object := func(body string) {
+		offsets = append(offsets, out.Len())
+		fmt.Fprintf(&out, "%d 0 obj\n%s\nendobj\n", len(offsets), body)
+	}
+	stream := func(dict string, data []byte) {
+		offsets = append(offsets, out.Len())
+		fmt.Fprintf(&out, "%d 0 obj\n<< %s /Length %d >>\nstream\n", len(offsets), dict, len(data))
+		out.Write(data)
+		out.WriteString("\nendstream\nendobj\n")
+	}
+
+	const firstPageObj = 8
+	kids := make([]string, len(d.pages))
+	for i := range d.pages {
+		kids[i] = fmt.Sprintf("%d 0 R", firstPageObj+i*2)
+	}
+
+	out.WriteString("%PDF-1.4\n%\xe2\xe3\xcf\xd3\n")
+	object("<< /Type /Catalog /Pages 2 0 R >>")
+	object(fmt.Sprintf("<< /Type /Pages /Kids [%s] /Count %d >>", strings.Join(kids, " "), len(d.pages)))
+	object("<< /Type /Font /Subtype /Type0 /BaseFont /GoRegular /Encoding /Identity-H /DescendantFonts [4 0 R] /ToUnicode 7 0 R >>")
+	object(fmt.Sprintf("<< /Type /Font /Subtype /CIDFontType2 /BaseFont /GoRegular /CIDSystemInfo << /Registry (Adobe) /Ordering (Identity) /Supplement 0 >> /FontDescriptor 5 0 R /CIDToGIDMap /Identity /W [%s] >>", d.widthArray()))
+	object(fmt.Sprintf("<< /Type /FontDescriptor /FontName /GoRegular /Flags 32 /FontBBox [%s] /ItalicAngle 0 /Ascent %d /Descent %d /CapHeight %d /StemV 80 /FontFile2 6 0 R >>", bbox, ascent, descent, ascent))
+	stream(fmt.Sprintf("/Filter /FlateDecode /Length1 %d", len(goregular.TTF)), fontFile)
+	stream("/Filter /FlateDecode", toUnicode)
+
+	for i, page := range d.pages {
+		content, err := compress(page.Bytes())
+		if err != nil {
+			return err
+		}
+		object(fmt.Sprintf("<< /Type /Page /Parent 2 0 R /MediaBox [0 0 %.2f %.2f] /Resources << /Font << /F1 3 0 R >> >> /Contents %d 0 R >>", pageWidth, pageHeight, firstPageObj+i*2+1))
+		stream("/Filter /FlateDecode", content)
+	}
+
+	xref := out.Len()
+	fmt.Fprintf(&out, "xref\n0 %d\n0000000000 65535 f \n", len(offsets)+1)
+	for _, offset := range offsets {
+		fmt.Fprintf(&out, "%010d 00000 n \n", offset)
+	}
+	fmt.Fprintf(&out, "trailer\n<< /Size %d /Root 1 0 R >>\nstartxref\n%d\n%%%%EOF\n", len(offsets)+1, xref)
+
+	_, err = w.Write(out.Bytes())
+	return err
+}
+
+func (d *Document) newPage() {
+	d.pages = append(d.pages, &bytes.Buffer{})
+	d.y = pageHeight - margin
+}
+
+func (d *Document) ensureSpace(height float64) {
+	if d.y-height < margin {
+		d.newPage()
+	}
+}
+
+func (d *Document) line(text string, size, x float64) {
+	d.ensureSpace(size * lineFactor)
+	d.y -= size * lineFactor
+	d.text(text, size, x, d.y)
+}
+
+func (d *Document) text(text string, size, x, y float64) {
+	if text == "" {
+		return
+	}
+
+	var hex strings.Builder
+	for _, r := range text {
+		fmt.Fprintf(&hex, "%04X", uint16(d.glyph(r)))
+	}
+
+	page := d.pages[len(d.pages)-1]
+	fmt.Fprintf(page, "BT /F1 %.1f Tf %.2f %.2f Td <%s> Tj ET\n", size, x, y, hex.String())
+}
+
+func (d *Document) glyph(r rune) sfnt.GlyphIndex {
+	idx, err := d.font.GlyphIndex(&d.buf, r)
+	if err != nil || idx == 0 {
+		r = '?'
+		idx, _ = d.font.GlyphIndex(&d.buf, r)
+	}
+
+	if _, ok := d.glyphs[idx]; !ok {
+		d.glyphs[idx] = r
+		d.widths[idx] = d.advance(idx)
+	}
+	return idx
+}
+
+func (d *Document) advance(idx sfnt.GlyphIndex) float64 {
+	adv, err := d.font.GlyphAdvance(&d.buf, idx, fixed.I(int(d.unitsPerEm)), font.HintingNone)
+	if err != nil {
+		return 0
+	}
+	return float64(adv) / 64 / d.unitsPerEm * 1000
+}
+
+func (d *Document) width(text string, size float64) float64 {
+	total := 0.0
+	for _, r := range text {
+		idx, err := d.font.GlyphIndex(&d.buf, r)
+		if err != nil || idx == 0 {
+			idx, _ = d.font.GlyphIndex(&d.buf, '?')
+		}
+		total += d.advance(idx)
+	}
+	return total * size / 1000
+}
+
+func (d *Document) wrap(text string, size, maxWidth float64) []string {
+	words := strings.Fields(text)
+	if len(words) == 0 {
+		return []string{""}
+	}
+
+	lines := make([]string, 0)
+	current := words[0]
+	for _, word := range words[1:] {
+		candidate := current + " " + word
+		if d.width(candidate, size) > maxWidth {
+			lines = append(lines, current)
+			current = word
+			continue
+		}
+		current = candidate
+	}
+
+	return append(lines, current)
+}
+
+func (d *Document) truncate(text string, size, maxWidth float64) string {
+	if d.width(text, size) <= maxWidth {
+		return text
+	}
+
+	runes := []rune(text)
+	for len(runes) > 0 && d.width(string(runes)+"…", size) > maxWidth {
+		runes = runes[:len(runes)-1]
+	}
+	return string(runes) + "…"
+}
+
+func (d *Document) sortedGlyphs() []sfnt.GlyphIndex {
+	ids := make([]sfnt.GlyphIndex, 0, len(d.glyphs))
+	for idx := range d.glyphs {
+		ids = append(ids, idx)
+	}
+	sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
+	return ids
+}
+
+func (d *Document) widthArray() string {
+	var b strings.Builder
+	for _, idx := range d.sortedGlyphs() {
+		fmt.Fprintf(&b, "%d [%.0f] ", idx, d.widths[idx])
+	}
+	return strings.TrimSpace(b.String())
+}
+
+func (d *Document) toUnicodeCMap() string {
+	ids := d.sortedGlyphs()
+
+	var b strings.Builder
+	b.WriteString("/CIDInit /ProcSet findresource begin\n12 dict begin\nbegincmap\n")
+	b.WriteString("/CIDSystemInfo << /Registry (Adobe) /Ordering (UCS) /Supplement 0 >> def\n")
+	b.WriteString("/CMapName /Adobe-Identity-UCS def\n/CMapType 2 def\n")
+	b.WriteString("1 begincodespacerange\n<0000> <FFFF>\nendcodespacerange\n")
+
+	for start := 0; start < len(ids); start += 100 {
+		end := min(start+100, len(ids))
+		fmt.Fprintf(&b, "%d beginbfchar\n", end-start)
+		for _, idx := range ids[start:end] {
+			fmt.Fprintf(&b, "<%04X> <%s>\n", uint16(idx), utf16Hex(d.glyphs[idx]))
+		}
+		b.WriteString("endbfchar\n")
+	}
+
+	b.WriteString("endcmap\nCMapName currentdict /CMap defineresource pop\nend\nend\n")
+	return b.String()
+}
+
+func (d *Document) metrics() (int, int, string, error) {
+	ppem := fixed.I(int(d.unitsPerEm))
+
+	m, err := d.font.Metrics(&d.buf, ppem, font.HintingNone)
+	if err != nil {
+		return 0, 0, "", fmt.Errorf("failed to read font metrics: %w", err)
+	}
+	bounds, err := d.font.Bounds(&d.buf, ppem, font.HintingNone)
+	if err != nil {
+		return 0, 0, "", fmt.Errorf("failed to read font bounds: %w", err)
+	}
+
+	scale := func(v fixed.Int26_6) int {
+		return int(float64(v) / 64 / d.unitsPerEm * 1000)
+	}
+	bbox := fmt.Sprintf("%d %d %d %d", scale(bounds.Min.X), -scale(bounds.Max.Y), scale(bounds.Max.X), -scale(bounds.Min.Y))
+
+	return scale(m.Ascent), -scale(m.Descent), bbox, nil
+}
+
+func utf16Hex(r rune) string {
+	if r < 0x10000 {
+		return fmt.Sprintf("%04X", r)
+	}
+	r -= 0x10000
+	return fmt.Sprintf("%04X%04X", 0xD800+(r>>10), 0xDC00+(r&0x3FF))
+}
+
+func compress(data []byte) ([]byte, error) {
+	var buf bytes.Buffer
+	zw := zlib.NewWriter(&buf)
+	if _, err := zw.Write(data); err != nil {
+		return nil, err
+	}
+	if err := zw.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}